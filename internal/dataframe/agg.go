@@ -0,0 +1,208 @@
+package dataframe
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// aggFunc is an aggregate function of an aggExpr.
+type aggFunc int
+
+const (
+	aggCount aggFunc = iota
+	aggSum
+	aggMean
+	aggMin
+	aggMax
+)
+
+// String returns the name of the function.
+func (f aggFunc) String() string {
+	return [...]string{"count", "sum", "mean", "min", "max"}[f]
+}
+
+// Count returns an aggregate expression counting the non-null values of e.
+// Aggregate expressions can only be used by DataFrame.Agg and GroupBy.AggExpr,
+// optionally named with Alias.
+func (e Expr) Count() Expr {
+	return Expr{node: aggExpr{fn: aggCount, operand: e}}
+}
+
+// Sum returns an aggregate expression adding the non-null values of the
+// numeric e, or null if there are none.
+func (e Expr) Sum() Expr {
+	return Expr{node: aggExpr{fn: aggSum, operand: e}}
+}
+
+// Mean returns an aggregate expression with the float64 average of the
+// non-null values of the numeric e, or null if there are none.
+func (e Expr) Mean() Expr {
+	return Expr{node: aggExpr{fn: aggMean, operand: e}}
+}
+
+// Min returns an aggregate expression with the smallest non-null value of
+// the numeric or string e, or null if there are none.
+func (e Expr) Min() Expr {
+	return Expr{node: aggExpr{fn: aggMin, operand: e}}
+}
+
+// Max returns an aggregate expression with the largest non-null value of
+// the numeric or string e, or null if there are none.
+func (e Expr) Max() Expr {
+	return Expr{node: aggExpr{fn: aggMax, operand: e}}
+}
+
+// aggExpr reduces the values of its operand to a single value.
+type aggExpr struct {
+	fn      aggFunc
+	operand Expr
+}
+
+func (e aggExpr) eval(*DataFrame) (column, error) {
+	return nil, fmt.Errorf("evaluating %s: %w: aggregate expressions can only be used by Agg", e, ErrInvalidData)
+}
+
+func (e aggExpr) String() string {
+	return fmt.Sprintf("%s(%s)", e.fn, e.operand)
+}
+
+// aggregate evaluates the operand over the rows of df and reduces it to a
+// single value, nil for null.
+func (e aggExpr) aggregate(df *DataFrame) (interface{}, error) {
+	operand, err := e.operand.eval(df)
+	if err != nil {
+		return nil, err
+	}
+
+	dt := operand.dtype()
+	switch {
+	case e.fn == aggCount:
+		return int64(operand.len() - operand.nullCount()), nil
+	case dt == Null:
+		return nil, nil
+	case dt == Int64 && e.fn != aggMean:
+		return reduce(e.fn, nonNull[int64](operand))
+	case dt == Int64 || dt == Float64:
+		if operand, err = castColumn(operand, Float64); err != nil {
+			return nil, err
+		}
+		return reduce(e.fn, nonNull[float64](operand))
+	case dt == String && (e.fn == aggMin || e.fn == aggMax):
+		return reduce(e.fn, nonNull[string](operand))
+	default:
+		return nil, fmt.Errorf("evaluating %s: %w: cannot aggregate %s values", e, ErrTypeMismatch, dt)
+	}
+}
+
+// reduce applies the aggregate function fn, other than count, to values.
+func reduce[T cmp.Ordered](fn aggFunc, values []T) (interface{}, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	switch fn {
+	case aggMin:
+		return slices.Min(values), nil
+	case aggMax:
+		return slices.Max(values), nil
+	}
+
+	var sum T
+	for _, v := range values {
+		sum += v
+	}
+	if fn == aggMean {
+		return any(sum).(float64) / float64(len(values)), nil
+	}
+	return sum, nil
+}
+
+// nonNull returns the non-null values of the typed column c.
+func nonNull[T any](c column) []T {
+	data := typedValues[T](c)
+	if c.nullCount() == 0 {
+		return data
+	}
+	result := make([]T, 0, len(data)-c.nullCount())
+	for i, v := range data {
+		if !c.isNull(i) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// asAggregate returns the aggregate expression of e, which may be aliased.
+func asAggregate(e Expr) (aggExpr, error) {
+	node := e.node
+	if alias, ok := node.(aliasExpr); ok {
+		node = alias.operand.node
+	}
+	agg, ok := node.(aggExpr)
+	if !ok {
+		return aggExpr{}, fmt.Errorf("%w: %s is not an aggregate expression", ErrInvalidData, e)
+	}
+	return agg, nil
+}
+
+// Agg returns a DataFrame with a single row holding the result of each
+// aggregate expression over all rows, such as Col("price").Sum().
+// The columns are named after the aliases or descriptions of the expressions.
+//
+// Returns ErrInvalidData if no expressions are given or one isn't an aggregate.
+// Returns ErrColumnNotFound if an expression refers to a missing column.
+// Returns ErrTypeMismatch if an expression can't aggregate the values of its column.
+func (df *DataFrame) Agg(exprs ...Expr) (*DataFrame, error) {
+	if len(exprs) == 0 {
+		return nil, fmt.Errorf("aggregating: %w: no aggregate expressions specified", ErrInvalidData)
+	}
+
+	data := make([]interface{}, len(exprs))
+	names := make([]string, len(exprs))
+	for i, e := range exprs {
+		agg, err := asAggregate(e)
+		if err != nil {
+			return nil, fmt.Errorf("aggregating: %w", err)
+		}
+		value, err := agg.aggregate(df)
+		if err != nil {
+			return nil, fmt.Errorf("aggregating: %w", err)
+		}
+		data[i] = []interface{}{value}
+		names[i] = e.outputName()
+	}
+
+	return New(data, names)
+}
+
+// aggregateGroups evaluates the aggregate expressions over the rows of each
+// group, returning one column of results per expression.
+func (dfg *GroupBy) aggregateGroups(ctx context.Context) ([]interface{}, []string, error) {
+	data := make([]interface{}, len(dfg.exprs))
+	names := make([]string, len(dfg.exprs))
+	for i, e := range dfg.exprs {
+		agg, err := asAggregate(e)
+		if err != nil {
+			return nil, nil, fmt.Errorf("aggregating groups: %w", err)
+		}
+
+		results := make([]interface{}, len(dfg.keys))
+		for j, key := range dfg.keys {
+			// Check context periodically
+			select {
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			default:
+			}
+
+			if results[j], err = agg.aggregate(dfg.df.take(dfg.indexes[j])); err != nil {
+				return nil, nil, fmt.Errorf("aggregating group %v: %w", key, err)
+			}
+		}
+		data[i] = results
+		names[i] = e.outputName()
+	}
+	return data, names, nil
+}
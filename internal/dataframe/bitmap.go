@@ -0,0 +1,62 @@
+package dataframe
+
+import "math/bits"
+
+// bitmap records which values of a column are valid, that is not null,
+// with one bit per value. A nil bitmap means that every value is valid.
+type bitmap []uint64
+
+// newBitmap returns a bitmap of n values, all of them null.
+func newBitmap(n int) bitmap {
+	return make(bitmap, (n+63)/64)
+}
+
+// newValidBitmap returns a bitmap of n values, all of them valid.
+func newValidBitmap(n int) bitmap {
+	b := newBitmap(n)
+	for i := range n {
+		b.set(i)
+	}
+	return b
+}
+
+// valid reports whether the i-th value is not null.
+func (b bitmap) valid(i int) bool {
+	return b == nil || b[i/64]&(1<<(i%64)) != 0
+}
+
+// set marks the i-th value as valid.
+func (b bitmap) set(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+// clear marks the i-th value as null.
+func (b bitmap) clear(i int) {
+	b[i/64] &^= 1 << (i % 64)
+}
+
+// nulls returns the number of null values among the first n values.
+func (b bitmap) nulls(n int) int {
+	if b == nil {
+		return 0
+	}
+	valid := 0
+	for _, w := range b {
+		valid += bits.OnesCount64(w)
+	}
+	return n - valid
+}
+
+// slice returns the validity of the values from lo up to hi.
+func (b bitmap) slice(lo, hi int) bitmap {
+	if b == nil {
+		return nil
+	}
+	sliced := newBitmap(hi - lo)
+	for i := lo; i < hi; i++ {
+		if b.valid(i) {
+			sliced.set(i - lo)
+		}
+	}
+	return sliced
+}
@@ -0,0 +1,148 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Cast returns an expression converting the values of e to the data type dt.
+// Numbers and booleans convert to each other, with true as 1 and nonzero
+// numbers as true, and floats are truncated to integers. Every type converts
+// to String. Strings that don't parse as dt, and floats out of the int64
+// range, become null.
+func (e Expr) Cast(dt DType) Expr {
+	return Expr{node: castExpr{operand: e, dt: dt}}
+}
+
+// castExpr converts its operand to a data type.
+type castExpr struct {
+	operand Expr
+	dt      DType
+}
+
+func (e castExpr) eval(df *DataFrame) (column, error) {
+	operand, err := e.operand.eval(df)
+	if err != nil {
+		return nil, err
+	}
+	result, err := castColumn(operand, e.dt)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating %s: %w", e, err)
+	}
+	return result, nil
+}
+
+func (e castExpr) String() string {
+	return fmt.Sprintf("CAST(%s AS %s)", e.operand, e.dt)
+}
+
+// castColumn converts the values of c to the data type dt.
+//
+// Returns ErrTypeMismatch if values of type c.dtype() can't be converted to dt.
+func castColumn(c column, dt DType) (column, error) {
+	from := c.dtype()
+	switch {
+	case from == dt:
+		return c, nil
+	case from == Null && dt != Any:
+		return nullsOf(dt, c.len()), nil
+	}
+
+	switch from {
+	case Int64:
+		switch dt {
+		case Float64:
+			return convert(c, dt, func(v int64) (float64, bool) { return float64(v), true }), nil
+		case String:
+			return convert(c, dt, func(v int64) (string, bool) { return strconv.FormatInt(v, 10), true }), nil
+		case Bool:
+			return convert(c, dt, func(v int64) (bool, bool) { return v != 0, true }), nil
+		}
+	case Float64:
+		switch dt {
+		case Int64:
+			return convert(c, dt, func(v float64) (int64, bool) {
+				t := math.Trunc(v)
+				return int64(t), t >= math.MinInt64 && t < math.MaxInt64
+			}), nil
+		case String:
+			return convert(c, dt, func(v float64) (string, bool) { return strconv.FormatFloat(v, 'g', -1, 64), true }), nil
+		case Bool:
+			return convert(c, dt, func(v float64) (bool, bool) { return v != 0, true }), nil
+		}
+	case String:
+		switch dt {
+		case Int64:
+			return convert(c, dt, func(v string) (int64, bool) {
+				n, err := strconv.ParseInt(v, 10, 64)
+				return n, err == nil
+			}), nil
+		case Float64:
+			return convert(c, dt, func(v string) (float64, bool) {
+				f, err := strconv.ParseFloat(v, 64)
+				return f, err == nil
+			}), nil
+		case Bool:
+			return convert(c, dt, func(v string) (bool, bool) {
+				b, err := strconv.ParseBool(v)
+				return b, err == nil
+			}), nil
+		}
+	case Bool:
+		switch dt {
+		case Int64:
+			return convert(c, dt, func(v bool) (int64, bool) { return boolTo[int64](v), true }), nil
+		case Float64:
+			return convert(c, dt, func(v bool) (float64, bool) { return boolTo[float64](v), true }), nil
+		case String:
+			return convert(c, dt, func(v bool) (string, bool) { return strconv.FormatBool(v), true }), nil
+		}
+	case Any:
+		if dt == String {
+			return convert(c, dt, func(v interface{}) (string, bool) { return fmt.Sprint(v), true }), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: cannot cast %s to %s", ErrTypeMismatch, from, dt)
+}
+
+// convert applies fn to the non-null values of the column c of S values,
+// returning a column of type dt. The result is null where fn reports false.
+func convert[S, T any](c column, dt DType, fn func(S) (T, bool)) column {
+	data := typedValues[S](c)
+	result := &typedColumn[T]{dt: dt, data: make([]T, len(data)), valid: newBitmap(len(data))}
+	for i, v := range data {
+		if c.isNull(i) {
+			continue
+		}
+		if converted, ok := fn(v); ok {
+			result.data[i] = converted
+			result.valid.set(i)
+		}
+	}
+	return result
+}
+
+// boolTo returns 1 for true and 0 for false.
+func boolTo[T int64 | float64](v bool) T {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// nullsOf returns a column of type dt holding n null values.
+func nullsOf(dt DType, n int) column {
+	switch dt {
+	case Int64:
+		return &typedColumn[int64]{dt: dt, data: make([]int64, n), valid: newBitmap(n)}
+	case Float64:
+		return &typedColumn[float64]{dt: dt, data: make([]float64, n), valid: newBitmap(n)}
+	case String:
+		return &typedColumn[string]{dt: dt, data: make([]string, n), valid: newBitmap(n)}
+	case Bool:
+		return nullBools(n)
+	default:
+		return &nullColumn{n: n}
+	}
+}
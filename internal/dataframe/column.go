@@ -0,0 +1,250 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// column is the typed storage of the values of a DataFrame column.
+type column interface {
+	// dtype returns the data type of the values.
+	dtype() DType
+	// len returns the number of values.
+	len() int
+	// value returns the i-th value boxed in an interface, or nil if it is null.
+	value(i int) interface{}
+	// isNull reports whether the i-th value is null.
+	isNull(i int) bool
+	// nullCount returns the number of null values.
+	nullCount() int
+	// take returns a new column with the values at the given indexes.
+	take(indexes []int) column
+	// slice returns the values from lo up to hi, sharing the storage of the column.
+	slice(lo, hi int) column
+}
+
+// typedColumn stores the values of a column in a slice of their Go type:
+// int64, float64, string, bool, or interface{} for Any columns.
+// Null values are recorded in the validity bitmap and hold the zero value.
+type typedColumn[T any] struct {
+	dt    DType
+	data  []T
+	valid bitmap
+}
+
+func (c *typedColumn[T]) dtype() DType { return c.dt }
+
+func (c *typedColumn[T]) len() int { return len(c.data) }
+
+func (c *typedColumn[T]) value(i int) interface{} {
+	if !c.valid.valid(i) {
+		return nil
+	}
+	return c.data[i]
+}
+
+func (c *typedColumn[T]) isNull(i int) bool { return !c.valid.valid(i) }
+
+func (c *typedColumn[T]) nullCount() int { return c.valid.nulls(len(c.data)) }
+
+func (c *typedColumn[T]) take(indexes []int) column {
+	taken := &typedColumn[T]{dt: c.dt, data: make([]T, len(indexes))}
+	if c.valid != nil {
+		taken.valid = newBitmap(len(indexes))
+	}
+	for i, idx := range indexes {
+		taken.data[i] = c.data[idx]
+		if c.valid != nil && c.valid.valid(idx) {
+			taken.valid.set(i)
+		}
+	}
+	return taken
+}
+
+func (c *typedColumn[T]) slice(lo, hi int) column {
+	return &typedColumn[T]{dt: c.dt, data: c.data[lo:hi:hi], valid: c.valid.slice(lo, hi)}
+}
+
+// nullColumn is a column of n null values of unknown type.
+type nullColumn struct {
+	n int
+}
+
+func (c *nullColumn) dtype() DType { return Null }
+
+func (c *nullColumn) len() int { return c.n }
+
+func (c *nullColumn) value(int) interface{} { return nil }
+
+func (c *nullColumn) isNull(int) bool { return true }
+
+func (c *nullColumn) nullCount() int { return c.n }
+
+func (c *nullColumn) take(indexes []int) column { return &nullColumn{n: len(indexes)} }
+
+func (c *nullColumn) slice(lo, hi int) column { return &nullColumn{n: hi - lo} }
+
+// boxValues returns the values of c boxed in interfaces.
+func boxValues(c column) []interface{} {
+	values := make([]interface{}, c.len())
+	for i := range values {
+		values[i] = c.value(i)
+	}
+	return values
+}
+
+// newColumn builds a column from data, which is either a slice of values
+// of any supported type or a typed slice of int64, int, int8, int16, int32,
+// float64, float32, string or bool values. Integers are widened to int64 and
+// floats to float64. The data is copied.
+//
+// Returns ErrInvalidData if data is not one of these slices and
+// ErrTypeMismatch if its values do not share a type.
+func newColumn(data interface{}) (column, error) {
+	switch values := data.(type) {
+	case []interface{}:
+		return inferColumn(values)
+	case []int64:
+		return &typedColumn[int64]{dt: Int64, data: append([]int64(nil), values...)}, nil
+	case []int:
+		return widenColumn[int, int64](Int64, values), nil
+	case []int8:
+		return widenColumn[int8, int64](Int64, values), nil
+	case []int16:
+		return widenColumn[int16, int64](Int64, values), nil
+	case []int32:
+		return widenColumn[int32, int64](Int64, values), nil
+	case []float64:
+		return &typedColumn[float64]{dt: Float64, data: append([]float64(nil), values...)}, nil
+	case []float32:
+		return widenColumn[float32, float64](Float64, values), nil
+	case []string:
+		return &typedColumn[string]{dt: String, data: append([]string(nil), values...)}, nil
+	case []bool:
+		return &typedColumn[bool]{dt: Bool, data: append([]bool(nil), values...)}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported column type %T", ErrInvalidData, data)
+	}
+}
+
+// widenColumn copies integer or floating-point values into a column of
+// their widened type U.
+func widenColumn[T, U int | int8 | int16 | int32 | int64 | float32 | float64](dt DType, values []T) column {
+	data := make([]U, len(values))
+	for i, v := range values {
+		data[i] = U(v)
+	}
+	return &typedColumn[U]{dt: dt, data: data}
+}
+
+// inferColumn builds a column from boxed values, inferring its type from
+// the first non-nil value. Nil values are nulls, and a column of only nulls
+// has the Null type. Integer values are widened to int64 and floating-point
+// values to float64.
+func inferColumn(values []interface{}) (column, error) {
+	first := slices.IndexFunc(values, func(v interface{}) bool { return v != nil })
+	if first == -1 {
+		return &nullColumn{n: len(values)}, nil
+	}
+
+	dt, goType := valueType(values[first])
+	switch dt {
+	case Int64:
+		return convertColumn(values, dt, goType, toInt64)
+	case Float64:
+		return convertColumn(values, dt, goType, toFloat64)
+	case String:
+		return convertColumn(values, dt, goType, func(v interface{}) string { return v.(string) })
+	case Bool:
+		return convertColumn(values, dt, goType, func(v interface{}) bool { return v.(bool) })
+	default:
+		return convertColumn(values, dt, goType, func(v interface{}) interface{} { return v })
+	}
+}
+
+// convertColumn converts every non-nil value to T, failing if a value does
+// not have the data type dt, or for Any columns the Go type goType. Nil
+// values are recorded as nulls.
+func convertColumn[T any](values []interface{}, dt DType, goType reflect.Type, convert func(interface{}) T) (column, error) {
+	data := make([]T, len(values))
+	valid := newBitmap(len(values))
+	hasNulls := false
+	for i, v := range values {
+		if v == nil {
+			hasNulls = true
+			continue
+		}
+		valid.set(i)
+
+		vdt, vType := valueType(v)
+		if vdt != dt || (dt == Any && vType != goType) {
+			return nil, fmt.Errorf("%w: value %d is %s, expected %s", ErrTypeMismatch, i, typeName(vdt, vType), typeName(dt, goType))
+		}
+		data[i] = convert(v)
+	}
+	if !hasNulls {
+		valid = nil
+	}
+	return &typedColumn[T]{dt: dt, data: data, valid: valid}, nil
+}
+
+// valueType returns the data type of v and its Go type.
+func valueType(v interface{}) (DType, reflect.Type) {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return Int64, nil
+	case float32, float64:
+		return Float64, nil
+	case string:
+		return String, nil
+	case bool:
+		return Bool, nil
+	case nil:
+		return Null, nil
+	default:
+		return Any, reflect.TypeOf(v)
+	}
+}
+
+// typeName describes a data type for error messages.
+func typeName(dt DType, goType reflect.Type) string {
+	if dt == Any {
+		return goType.String()
+	}
+	return dt.String()
+}
+
+// toInt64 widens an integer value to int64.
+func toInt64(v interface{}) int64 {
+	switch n := v.(type) {
+	case int:
+		return int64(n)
+	case int8:
+		return int64(n)
+	case int16:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case int64:
+		return n
+	case uint:
+		return int64(n)
+	case uint8:
+		return int64(n)
+	case uint16:
+		return int64(n)
+	case uint32:
+		return int64(n)
+	default:
+		return int64(v.(uint64))
+	}
+}
+
+// toFloat64 widens a floating-point value to float64.
+func toFloat64(v interface{}) float64 {
+	if f, ok := v.(float32); ok {
+		return float64(f)
+	}
+	return v.(float64)
+}
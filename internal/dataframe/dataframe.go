@@ -4,6 +4,8 @@ package dataframe
 import (
 	"context"
 	"fmt"
+	"iter"
+	"slices"
 	"strings"
 
 	"mkubasz/quanto/internal/rdd"
@@ -17,39 +19,85 @@ type Series[T any] struct {
 
 // DataFrame represents a column-oriented data structure,
 // similar to a table with named columns.
-// Each column is stored as a Series for efficient column-wise operations.
+// Each column stores its values in a slice of their data type, as described
+// by the schema of the DataFrame.
 type DataFrame struct {
-	series  []Series[interface{}]
+	fields []Field
+	cols   []column
+	rows   int
+}
+
+// Row is a single row of a DataFrame, holding one value per column.
+type Row struct {
 	columns []string
-	size    int
+	values  []interface{}
+}
+
+// Get returns the value of the named column and whether the row has it.
+func (r Row) Get(name string) (interface{}, bool) {
+	idx := slices.Index(r.columns, name)
+	if idx == -1 {
+		return nil, false
+	}
+	return r.values[idx], true
+}
+
+// Values returns the values of the row in column order.
+func (r Row) Values() []interface{} {
+	return slices.Clone(r.values)
+}
+
+// Len returns the number of values in the row.
+func (r Row) Len() int {
+	return len(r.values)
 }
 
 // NewFromRDD creates a new DataFrame from an RDD.
 // The DataFrame will have a single column containing all RDD elements.
-func NewFromRDD[T any](r *rdd.RDD[T]) *DataFrame {
-	var series Series[interface{}]
-	for _, v := range r.Collect() {
-		series.Data = append(series.Data, v)
+// Building the DataFrame is an action that executes the RDD lineage.
+//
+// The context can be used to cancel the operation.
+func NewFromRDD[T any](ctx context.Context, r *rdd.RDD[T]) (*DataFrame, error) {
+	values, err := r.Collect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating dataframe from rdd: %w", err)
 	}
-	return &DataFrame{
-		size:    len(series.Data),
-		series:  []Series[interface{}]{series},
-		columns: []string{"value"}, // Default column name
+
+	boxed := make([]interface{}, len(values))
+	for i, v := range values {
+		boxed[i] = v
+	}
+	col, err := inferColumn(boxed)
+	if err != nil {
+		return nil, fmt.Errorf("creating dataframe from rdd: %w", err)
 	}
+
+	return &DataFrame{
+		rows:   col.len(),
+		fields: []Field{newField("value", col)}, // Default column name
+		cols:   []column{col},
+	}, nil
 }
 
 // New creates a new DataFrame from the provided data and column names.
-// Data should be a slice where each element represents a column's data.
+// Data should be a slice where each element represents a column's data,
+// either as a []interface{} or as a typed slice of int64, int, int8, int16,
+// int32, float64, float32, string or bool values. The type of each column is inferred from its
+// values: integers are stored as int64 and floating-point numbers as float64.
+// Nil values in a []interface{} column are nulls.
 //
-// Returns ErrInvalidData if data format is invalid.
+// Returns ErrInvalidData if data format is invalid or columns have different lengths.
+// Returns ErrTypeMismatch if the values of a column have different types.
 // Returns ErrInvalidColumnName if column names are empty or don't match data.
 func New(data []interface{}, columns []string) (*DataFrame, error) {
 	if len(data) == 0 {
-		return &DataFrame{
-			size:    0,
-			series:  []Series[interface{}]{},
-			columns: columns,
-		}, nil
+		df := &DataFrame{}
+		for _, name := range columns {
+			col := &nullColumn{}
+			df.fields = append(df.fields, newField(name, col))
+			df.cols = append(df.cols, col)
+		}
+		return df, nil
 	}
 
 	if len(columns) == 0 {
@@ -68,28 +116,24 @@ func New(data []interface{}, columns []string) (*DataFrame, error) {
 		}
 	}
 
-	var series []Series[interface{}]
-	totalSize := 0
+	df := &DataFrame{}
+	for i, values := range data {
+		col, err := newColumn(values)
+		if err != nil {
+			return nil, fmt.Errorf("creating dataframe: column %d (%s): %w", i, columns[i], err)
+		}
 
-	for i, row := range data {
-		rowSlice, ok := row.([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("creating dataframe: %w: column %d (%s) is not a slice",
-				ErrInvalidData, i, columns[i])
+		if i > 0 && col.len() != df.rows {
+			return nil, fmt.Errorf("creating dataframe: %w: column %d (%s) has %d rows, expected %d",
+				ErrInvalidData, i, columns[i], col.len(), df.rows)
 		}
 
-		var serie Series[interface{}]
-		serie.Data = make([]interface{}, len(rowSlice))
-		copy(serie.Data, rowSlice)
-		series = append(series, serie)
-		totalSize += len(rowSlice)
+		df.fields = append(df.fields, newField(columns[i], col))
+		df.cols = append(df.cols, col)
+		df.rows = col.len()
 	}
 
-	return &DataFrame{
-		size:    totalSize,
-		series:  series,
-		columns: columns,
-	}, nil
+	return df, nil
 }
 
 // Select returns the Series (column) with the specified name.
@@ -107,52 +151,146 @@ func (df *DataFrame) Select(columnName string) (Series[interface{}], error) {
 	}
 
 	// Return a copy to ensure immutability
-	series := df.series[idx]
-	dataCopy := make([]interface{}, len(series.Data))
-	copy(dataCopy, series.Data)
-
-	return Series[interface{}]{Data: dataCopy}, nil
+	return Series[interface{}]{Data: boxValues(df.cols[idx])}, nil
 }
 
 // getColumnIndex returns the index of a column by name.
 // Returns ErrColumnNotFound if the column doesn't exist.
 func (df *DataFrame) getColumnIndex(name string) (int, error) {
-	for idx, column := range df.columns {
-		if column == name {
+	for idx, field := range df.fields {
+		if field.Name == name {
 			return idx, nil
 		}
 	}
 	return -1, ErrColumnNotFound
 }
 
-// HasColumn returns true if the DataFrame has a column with the specified name.
-func (df *DataFrame) HasColumn(name string) bool {
-	for _, column := range df.columns {
-		if column == name {
-			return true
+// SelectExpr returns a new DataFrame with one column per expression, such
+// as Col("price").Mul(Col("quantity")).Alias("total"). The columns are named
+// after the aliases of the expressions, the columns they refer to, or their
+// descriptions.
+//
+// Returns ErrInvalidData if no expressions are given.
+// Returns ErrColumnNotFound if an expression refers to a missing column.
+// Returns ErrTypeMismatch if an expression combines incompatible types.
+func (df *DataFrame) SelectExpr(exprs ...Expr) (*DataFrame, error) {
+	if len(exprs) == 0 {
+		return nil, fmt.Errorf("selecting expressions: %w: no expressions specified", ErrInvalidData)
+	}
+
+	result := &DataFrame{rows: df.rows}
+	for _, e := range exprs {
+		col, err := e.eval(df)
+		if err != nil {
+			return nil, fmt.Errorf("selecting %s: %w", e, err)
 		}
+		result.fields = append(result.fields, newField(e.outputName(), col))
+		result.cols = append(result.cols, col)
 	}
-	return false
+	return result, nil
+}
+
+// WithColumn returns a new DataFrame with a column named name computed by
+// the expression, replacing the column with that name if there is one.
+//
+// Returns ErrInvalidColumnName if the column name is empty.
+// Returns ErrColumnNotFound if the expression refers to a missing column.
+// Returns ErrTypeMismatch if the expression combines incompatible types.
+func (df *DataFrame) WithColumn(name string, expr Expr) (*DataFrame, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("adding column: %w: column name is empty", ErrInvalidColumnName)
+	}
+
+	col, err := expr.eval(df)
+	if err != nil {
+		return nil, fmt.Errorf("adding column %s: %w", name, err)
+	}
+
+	result := &DataFrame{
+		fields: slices.Clone(df.fields),
+		cols:   slices.Clone(df.cols),
+		rows:   df.rows,
+	}
+	if idx, err := df.getColumnIndex(name); err == nil {
+		result.fields[idx] = newField(name, col)
+		result.cols[idx] = col
+	} else {
+		result.fields = append(result.fields, newField(name, col))
+		result.cols = append(result.cols, col)
+	}
+	return result, nil
+}
+
+// take returns a new DataFrame with the rows at the given indexes.
+func (df *DataFrame) take(indexes []int) *DataFrame {
+	cols := make([]column, len(df.cols))
+	for i, col := range df.cols {
+		cols[i] = col.take(indexes)
+	}
+	return df.withColumns(cols)
+}
+
+// withColumns returns a new DataFrame with the column names of df and the
+// given columns, which must all have the same length.
+func (df *DataFrame) withColumns(cols []column) *DataFrame {
+	result := &DataFrame{cols: cols}
+	for i, col := range cols {
+		result.fields = append(result.fields, newField(df.fields[i].Name, col))
+		result.rows = col.len()
+	}
+	return result
+}
+
+// HasColumn returns true if the DataFrame has a column with the specified name.
+func (df *DataFrame) HasColumn(name string) bool {
+	_, err := df.getColumnIndex(name)
+	return err == nil
 }
 
 // Columns returns the names of all columns in the DataFrame.
 func (df *DataFrame) Columns() []string {
-	cols := make([]string, len(df.columns))
-	copy(cols, df.columns)
-	return cols
+	return df.Schema().Names()
 }
 
-// Size returns the total number of elements across all columns.
+// Size returns the total number of elements across all columns,
+// that is the number of rows times the number of columns.
 func (df *DataFrame) Size() int {
-	return df.size
+	return df.rows * len(df.fields)
+}
+
+// NumRows returns the number of rows in the DataFrame.
+func (df *DataFrame) NumRows() int {
+	return df.rows
+}
+
+// Shape returns the number of rows and columns in the DataFrame.
+func (df *DataFrame) Shape() (rows, columns int) {
+	return df.rows, len(df.fields)
+}
+
+// Rows returns an iterator over the rows of the DataFrame and their indexes.
+func (df *DataFrame) Rows() iter.Seq2[int, Row] {
+	return func(yield func(int, Row) bool) {
+		names := df.Columns()
+		for i := range df.rows {
+			values := make([]interface{}, len(df.cols))
+			for j, col := range df.cols {
+				values[j] = col.value(i)
+			}
+			if !yield(i, Row{columns: names, values: values}) {
+				return
+			}
+		}
+	}
 }
 
 // NumColumns returns the number of columns in the DataFrame.
 func (df *DataFrame) NumColumns() int {
-	return len(df.columns)
+	return len(df.fields)
 }
 
 // Distinct returns a new Series containing only unique values.
+// Null values are equal to each other, so at most one nil value is kept.
 // The key parameter is ignored and kept for backward compatibility.
 //
 // Returns ErrEmptyDataFrame if the series is empty.
@@ -184,6 +322,11 @@ func (s Series[T]) Distinct(ctx context.Context, _ string) (Series[interface{}],
 	return Series[interface{}]{Data: distinctValues}, nil
 }
 
+// Values returns an iterator over the values of the Series.
+func (s Series[T]) Values() iter.Seq[T] {
+	return slices.Values(s.Data)
+}
+
 // Count returns the number of elements in the Series.
 func (s Series[T]) Count() int {
 	return len(s.Data)
@@ -192,7 +335,7 @@ func (s Series[T]) Count() int {
 // String returns a string representation of the DataFrame.
 func (df *DataFrame) String() string {
 	return fmt.Sprintf("DataFrame[rows=%d, columns=%d: %s]",
-		df.size, len(df.columns), strings.Join(df.columns, ", "))
+		df.rows, len(df.fields), strings.Join(df.Columns(), ", "))
 }
 
 // String returns a string representation of the Series.
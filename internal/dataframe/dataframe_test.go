@@ -3,12 +3,24 @@ package dataframe_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 
 	"mkubasz/quanto/internal/dataframe"
 	"mkubasz/quanto/internal/rdd"
 )
 
+// mustNew creates a DataFrame from the given columns of data, failing the
+// test on error.
+func mustNew(t *testing.T, data []interface{}, columns []string) *dataframe.DataFrame {
+	t.Helper()
+	df, err := dataframe.New(data, columns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return df
+}
+
 // TestNewFromRDD verifies DataFrame creation from RDD.
 func TestNewFromRDD(t *testing.T) {
 	tests := []struct {
@@ -40,10 +52,10 @@ func TestNewFromRDD(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := rdd.New(tt.input)
-			df := dataframe.NewFromRDD(r)
+			df, err := dataframe.NewFromRDD(context.Background(), r)
 
-			if df == nil {
-				t.Error("expected non-nil DataFrame")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
 				return
 			}
 
@@ -101,6 +113,23 @@ func TestNew(t *testing.T) {
 			columns: []string{"col1"},
 			wantErr: dataframe.ErrInvalidData,
 		},
+		{
+			name: "unsupported column type",
+			data: []interface{}{
+				[]complex128{1, 2, 3},
+			},
+			columns: []string{"col1"},
+			wantErr: dataframe.ErrInvalidData,
+		},
+		{
+			name: "ragged columns",
+			data: []interface{}{
+				[]interface{}{1, 2, 3},
+				[]interface{}{4, 5},
+			},
+			columns: []string{"col1", "col2"},
+			wantErr: dataframe.ErrInvalidData,
+		},
 		{
 			name: "empty column name",
 			data: []interface{}{
@@ -146,6 +175,62 @@ func TestNew(t *testing.T) {
 	}
 }
 
+// TestShape verifies row and column counts.
+func TestShape(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []interface{}
+		columns  []string
+		wantRows int
+		wantCols int
+	}{
+		{
+			name: "two columns",
+			data: []interface{}{
+				[]interface{}{"A", "B", "C"},
+				[]int{1, 2, 3},
+			},
+			columns:  []string{"col1", "col2"},
+			wantRows: 3,
+			wantCols: 2,
+		},
+		{
+			name:     "empty data with columns",
+			data:     []interface{}{},
+			columns:  []string{"col1", "col2"},
+			wantRows: 0,
+			wantCols: 2,
+		},
+		{
+			name:     "empty columns",
+			data:     []interface{}{[]interface{}{}, []string{}},
+			columns:  []string{"col1", "col2"},
+			wantRows: 0,
+			wantCols: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df, err := dataframe.New(tt.data, tt.columns)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			rows, cols := df.Shape()
+			if rows != tt.wantRows || cols != tt.wantCols {
+				t.Errorf("shape = (%d, %d), want (%d, %d)", rows, cols, tt.wantRows, tt.wantCols)
+			}
+			if df.NumRows() != tt.wantRows {
+				t.Errorf("rows = %d, want %d", df.NumRows(), tt.wantRows)
+			}
+			if df.Size() != tt.wantRows*tt.wantCols {
+				t.Errorf("size = %d, want %d", df.Size(), tt.wantRows*tt.wantCols)
+			}
+		})
+	}
+}
+
 // TestSelect verifies column selection.
 func TestSelect(t *testing.T) {
 	tests := []struct {
@@ -391,6 +476,55 @@ func TestDataFrameImmutability(t *testing.T) {
 	}
 }
 
+// TestRows verifies iterating over the rows of a DataFrame.
+func TestRows(t *testing.T) {
+	df, _ := dataframe.New(
+		[]interface{}{
+			[]interface{}{"A", "B", "C"},
+			[]interface{}{1, 2, 3},
+		},
+		[]string{"name", "value"},
+	)
+
+	var names []interface{}
+	var total int64
+	for i, row := range df.Rows() {
+		if row.Len() != 2 {
+			t.Errorf("row %d has %d values, want 2", i, row.Len())
+		}
+		name, _ := row.Get("name")
+		names = append(names, name)
+		value, ok := row.Get("value")
+		if !ok {
+			t.Fatalf("row %d has no value column", i)
+		}
+		total += value.(int64)
+	}
+
+	if !slices.Equal(names, []interface{}{"A", "B", "C"}) {
+		t.Errorf("names = %v, want [A B C]", names)
+	}
+	if total != 6 {
+		t.Errorf("total = %d, want 6", total)
+	}
+
+	for _, row := range df.Rows() {
+		if _, ok := row.Get("missing"); ok {
+			t.Error("expected missing column to be reported")
+		}
+		break
+	}
+}
+
+// TestSeriesValues verifies iterating over the values of a Series.
+func TestSeriesValues(t *testing.T) {
+	series := dataframe.Series[int]{Data: []int{3, 1, 2}}
+
+	if got := slices.Collect(series.Values()); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("values = %v, want [3 1 2]", got)
+	}
+}
+
 // BenchmarkSelect benchmarks column selection.
 func BenchmarkSelect(b *testing.B) {
 	data := make([]interface{}, 10)
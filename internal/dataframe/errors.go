@@ -16,4 +16,7 @@ var (
 
 	// ErrInvalidData is returned when input data is malformed.
 	ErrInvalidData = errors.New("invalid data format")
+
+	// ErrTypeMismatch is returned when values do not have the expected type.
+	ErrTypeMismatch = errors.New("type mismatch")
 )
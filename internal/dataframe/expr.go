@@ -0,0 +1,754 @@
+package dataframe
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Expr is an expression computing a column from the columns of a DataFrame,
+// such as Col("age").Gt(Lit(30)). Expressions are evaluated vectorised, over
+// whole typed columns rather than row by row.
+//
+// A null operand makes the result of an expression null, except for the
+// boolean And and Or, which follow three-valued logic, and the null checks.
+type Expr struct {
+	node exprNode
+}
+
+// exprNode is a node of an expression tree.
+type exprNode interface {
+	// eval evaluates the node over the rows of df.
+	eval(df *DataFrame) (column, error)
+	// String describes the node, e.g. "(age > 30)".
+	String() string
+}
+
+// Col returns an expression referring to the named column.
+func Col(name string) Expr {
+	return Expr{node: colExpr{name: name}}
+}
+
+// Lit returns an expression with the same value in every row.
+// Integers are widened to int64 and floating-point numbers to float64,
+// and a nil value is a null literal.
+func Lit(value interface{}) Expr {
+	return Expr{node: litExpr{value: value}}
+}
+
+// String describes the expression, e.g. "((age > 30) AND (country = PL))".
+func (e Expr) String() string {
+	if e.node == nil {
+		return "<empty>"
+	}
+	return e.node.String()
+}
+
+// eval evaluates the expression over the rows of df.
+func (e Expr) eval(df *DataFrame) (column, error) {
+	if e.node == nil {
+		return nil, fmt.Errorf("%w: empty expression", ErrInvalidData)
+	}
+	return e.node.eval(df)
+}
+
+// Eq returns an expression that is true where e equals other.
+// As with Go's operators, comparisons involving a floating-point NaN are
+// false, except for Ne, which is true.
+func (e Expr) Eq(other Expr) Expr {
+	return Expr{node: compareExpr{op: opEq, left: e, right: other}}
+}
+
+// Ne returns an expression that is true where e doesn't equal other.
+func (e Expr) Ne(other Expr) Expr {
+	return Expr{node: compareExpr{op: opNe, left: e, right: other}}
+}
+
+// Lt returns an expression that is true where e is less than other.
+func (e Expr) Lt(other Expr) Expr {
+	return Expr{node: compareExpr{op: opLt, left: e, right: other}}
+}
+
+// Le returns an expression that is true where e is less than or equal to other.
+func (e Expr) Le(other Expr) Expr {
+	return Expr{node: compareExpr{op: opLe, left: e, right: other}}
+}
+
+// Gt returns an expression that is true where e is greater than other.
+func (e Expr) Gt(other Expr) Expr {
+	return Expr{node: compareExpr{op: opGt, left: e, right: other}}
+}
+
+// Ge returns an expression that is true where e is greater than or equal to other.
+func (e Expr) Ge(other Expr) Expr {
+	return Expr{node: compareExpr{op: opGe, left: e, right: other}}
+}
+
+// And returns an expression that is true where both e and other are true.
+// It is false where either is false, even if the other one is null.
+func (e Expr) And(other Expr) Expr {
+	return Expr{node: logicExpr{and: true, left: e, right: other}}
+}
+
+// Or returns an expression that is true where e or other is true.
+// It is true where either is true, even if the other one is null.
+func (e Expr) Or(other Expr) Expr {
+	return Expr{node: logicExpr{left: e, right: other}}
+}
+
+// Not returns an expression that is true where the boolean e is false.
+func (e Expr) Not() Expr {
+	return Expr{node: notExpr{operand: e}}
+}
+
+// IsNull returns an expression that is true where e is null.
+func (e Expr) IsNull() Expr {
+	return Expr{node: nullCheckExpr{operand: e}}
+}
+
+// IsNotNull returns an expression that is true where e is not null.
+func (e Expr) IsNotNull() Expr {
+	return Expr{node: nullCheckExpr{operand: e, not: true}}
+}
+
+// Add returns an expression adding other to e.
+func (e Expr) Add(other Expr) Expr {
+	return Expr{node: arithExpr{op: opAdd, left: e, right: other}}
+}
+
+// Sub returns an expression subtracting other from e.
+func (e Expr) Sub(other Expr) Expr {
+	return Expr{node: arithExpr{op: opSub, left: e, right: other}}
+}
+
+// Mul returns an expression multiplying e by other.
+func (e Expr) Mul(other Expr) Expr {
+	return Expr{node: arithExpr{op: opMul, left: e, right: other}}
+}
+
+// Div returns an expression dividing e by other. The result is always a
+// float64, and null where other is zero.
+func (e Expr) Div(other Expr) Expr {
+	return Expr{node: arithExpr{op: opDiv, left: e, right: other}}
+}
+
+// Mod returns an expression with the remainder of dividing e by other,
+// null where other is zero.
+func (e Expr) Mod(other Expr) Expr {
+	return Expr{node: arithExpr{op: opMod, left: e, right: other}}
+}
+
+// Alias returns e with its result column named name.
+func (e Expr) Alias(name string) Expr {
+	return Expr{node: aliasExpr{operand: e, name: name}}
+}
+
+// When returns an expression that is value in the rows where condition is
+// true. More branches are added with Expr.When and tried in order, and the
+// rows where no branch applies are null unless a default value is set with
+// Expr.Otherwise. The values must share a data type, except that integers
+// are widened to float64 if mixed with floats.
+func When(condition, value Expr) Expr {
+	return Expr{node: caseExpr{branches: []caseBranch{{condition: condition, value: value}}}}
+}
+
+// When adds a branch to an expression created by When.
+func (e Expr) When(condition, value Expr) Expr {
+	c, ok := e.node.(caseExpr)
+	if !ok || c.otherwise != nil {
+		return Expr{node: invalidExpr{desc: fmt.Sprintf("%s.When", e),
+			err: fmt.Errorf("%w: When can only follow When", ErrInvalidData)}}
+	}
+	c.branches = append(slices.Clip(c.branches), caseBranch{condition: condition, value: value})
+	return Expr{node: c}
+}
+
+// Otherwise sets the value of an expression created by When in the rows
+// where no branch applies.
+func (e Expr) Otherwise(value Expr) Expr {
+	c, ok := e.node.(caseExpr)
+	if !ok || c.otherwise != nil {
+		return Expr{node: invalidExpr{desc: fmt.Sprintf("%s.Otherwise", e),
+			err: fmt.Errorf("%w: Otherwise can only follow When", ErrInvalidData)}}
+	}
+	c.otherwise = &value
+	return Expr{node: c}
+}
+
+// outputName returns the name of the column computed by e: its alias,
+// the name of the column it refers to, or its description.
+func (e Expr) outputName() string {
+	switch n := e.node.(type) {
+	case aliasExpr:
+		return n.name
+	case colExpr:
+		return n.name
+	default:
+		return e.String()
+	}
+}
+
+// colExpr refers to a column by name.
+type colExpr struct {
+	name string
+}
+
+func (e colExpr) eval(df *DataFrame) (column, error) {
+	idx, err := df.getColumnIndex(e.name)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating column %s: %w", e.name, err)
+	}
+	return df.cols[idx], nil
+}
+
+func (e colExpr) String() string { return e.name }
+
+// litExpr is a literal value.
+type litExpr struct {
+	value interface{}
+}
+
+func (e litExpr) eval(df *DataFrame) (column, error) {
+	return constColumn(e.value, df.rows), nil
+}
+
+func (e litExpr) String() string {
+	if e.value == nil {
+		return "NULL"
+	}
+	return fmt.Sprint(e.value)
+}
+
+// constColumn returns a column of n copies of value.
+func constColumn(value interface{}, n int) column {
+	switch dt, _ := valueType(value); dt {
+	case Int64:
+		return repeat(dt, toInt64(value), n)
+	case Float64:
+		return repeat(dt, toFloat64(value), n)
+	case String:
+		return repeat(dt, value.(string), n)
+	case Bool:
+		return repeat(dt, value.(bool), n)
+	case Null:
+		return &nullColumn{n: n}
+	default:
+		return repeat(dt, value, n)
+	}
+}
+
+// repeat returns a column of n copies of v.
+func repeat[T any](dt DType, v T, n int) column {
+	data := make([]T, n)
+	for i := range data {
+		data[i] = v
+	}
+	return &typedColumn[T]{dt: dt, data: data}
+}
+
+// cmpOp is a comparison operator.
+type cmpOp int
+
+const (
+	opEq cmpOp = iota
+	opNe
+	opLt
+	opLe
+	opGt
+	opGe
+)
+
+// String returns the symbol of the operator.
+func (op cmpOp) String() string {
+	return [...]string{"=", "!=", "<", "<=", ">", ">="}[op]
+}
+
+// compareExpr compares two operands.
+type compareExpr struct {
+	op          cmpOp
+	left, right Expr
+}
+
+func (e compareExpr) eval(df *DataFrame) (column, error) {
+	left, right, err := evalOperands(df, e.left, e.right)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := andValidity(left, right)
+	switch lt, rt := left.dtype(), right.dtype(); {
+	case lt == Null || rt == Null:
+		return nullBools(left.len()), nil
+	case lt == Int64 && rt == Int64:
+		return compareSlices(e.op, typedValues[int64](left), typedValues[int64](right), valid), nil
+	case isNumeric(lt) && isNumeric(rt):
+		return compareSlices(e.op, floats(left), floats(right), valid), nil
+	case lt == String && rt == String:
+		return compareSlices(e.op, typedValues[string](left), typedValues[string](right), valid), nil
+	case lt == Bool && rt == Bool:
+		return compareSlices(e.op, boolInts(left), boolInts(right), valid), nil
+	default:
+		return nil, fmt.Errorf("evaluating %s: %w: cannot compare %s with %s", e, ErrTypeMismatch, lt, rt)
+	}
+}
+
+func (e compareExpr) String() string {
+	return fmt.Sprintf("(%s %s %s)", e.left, e.op, e.right)
+}
+
+// compareSlices compares the values of left and right pairwise with Go's
+// operators, so that a NaN is unequal to every value, itself included.
+func compareSlices[T cmp.Ordered](op cmpOp, left, right []T, valid bitmap) column {
+	result := make([]bool, len(left))
+	for i := range result {
+		l, r := left[i], right[i]
+		switch op {
+		case opEq:
+			result[i] = l == r
+		case opNe:
+			result[i] = l != r
+		case opLt:
+			result[i] = l < r
+		case opLe:
+			result[i] = l <= r
+		case opGt:
+			result[i] = l > r
+		case opGe:
+			result[i] = l >= r
+		}
+	}
+	return &typedColumn[bool]{dt: Bool, data: result, valid: valid}
+}
+
+// arithOp is an arithmetic operator.
+type arithOp int
+
+const (
+	opAdd arithOp = iota
+	opSub
+	opMul
+	opDiv
+	opMod
+)
+
+// String returns the symbol of the operator.
+func (op arithOp) String() string {
+	return [...]string{"+", "-", "*", "/", "%"}[op]
+}
+
+// arithExpr applies an arithmetic operator to two numeric operands.
+type arithExpr struct {
+	op          arithOp
+	left, right Expr
+}
+
+func (e arithExpr) eval(df *DataFrame) (column, error) {
+	left, right, err := evalOperands(df, e.left, e.right)
+	if err != nil {
+		return nil, err
+	}
+
+	lt, rt := left.dtype(), right.dtype()
+	if (lt != Null && !isNumeric(lt)) || (rt != Null && !isNumeric(rt)) {
+		return nil, fmt.Errorf("evaluating %s: %w: operands are %s and %s, expected numbers",
+			e, ErrTypeMismatch, lt, rt)
+	}
+
+	// Integers stay integers, except for division; a null operand takes the type of the other.
+	dt := Float64
+	switch {
+	case lt == Null && rt == Null:
+		return &nullColumn{n: left.len()}, nil
+	case e.op != opDiv && (lt == Int64 || lt == Null) && (rt == Int64 || rt == Null):
+		dt = Int64
+	}
+	if left, err = castColumn(left, dt); err != nil {
+		return nil, err
+	}
+	if right, err = castColumn(right, dt); err != nil {
+		return nil, err
+	}
+
+	valid := andValidity(left, right)
+	if dt == Int64 {
+		return arithmetic(dt, e.op, typedValues[int64](left), typedValues[int64](right), valid,
+			func(a, b int64) int64 { return a % b }), nil
+	}
+	return arithmetic(dt, e.op, typedValues[float64](left), typedValues[float64](right), valid, math.Mod), nil
+}
+
+func (e arithExpr) String() string {
+	return fmt.Sprintf("(%s %s %s)", e.left, e.op, e.right)
+}
+
+// arithmetic applies op to the values of left and right pairwise, using mod
+// for the remainder. Dividing by zero gives null.
+func arithmetic[T int64 | float64](dt DType, op arithOp, left, right []T, valid bitmap, mod func(T, T) T) column {
+	result := &typedColumn[T]{dt: dt, data: make([]T, len(left)), valid: valid}
+	for i := range left {
+		switch op {
+		case opAdd:
+			result.data[i] = left[i] + right[i]
+		case opSub:
+			result.data[i] = left[i] - right[i]
+		case opMul:
+			result.data[i] = left[i] * right[i]
+		case opDiv, opMod:
+			if right[i] == 0 {
+				if result.valid == nil {
+					result.valid = newValidBitmap(len(left))
+				}
+				result.valid.clear(i)
+				continue
+			}
+			if op == opDiv {
+				result.data[i] = left[i] / right[i]
+			} else {
+				result.data[i] = mod(left[i], right[i])
+			}
+		}
+	}
+	return result
+}
+
+// logicExpr combines two boolean operands with AND or OR.
+type logicExpr struct {
+	and         bool
+	left, right Expr
+}
+
+func (e logicExpr) eval(df *DataFrame) (column, error) {
+	left, right, err := evalOperands(df, e.left, e.right)
+	if err != nil {
+		return nil, err
+	}
+	if !isBoolean(left.dtype()) || !isBoolean(right.dtype()) {
+		return nil, fmt.Errorf("evaluating %s: %w: operands are %s and %s, expected bool",
+			e, ErrTypeMismatch, left.dtype(), right.dtype())
+	}
+
+	n := left.len()
+	lv, rv := boolValues(left), boolValues(right)
+	result := &typedColumn[bool]{dt: Bool, data: make([]bool, n), valid: newBitmap(n)}
+	for i := range n {
+		lnull, rnull := left.isNull(i), right.isNull(i)
+		// The result is decided by a single operand that is false for AND or true for OR.
+		if (!lnull && lv[i] != e.and) || (!rnull && rv[i] != e.and) {
+			result.data[i] = !e.and
+			result.valid.set(i)
+		} else if !lnull && !rnull {
+			result.data[i] = e.and
+			result.valid.set(i)
+		}
+	}
+	return result, nil
+}
+
+func (e logicExpr) String() string {
+	if e.and {
+		return fmt.Sprintf("(%s AND %s)", e.left, e.right)
+	}
+	return fmt.Sprintf("(%s OR %s)", e.left, e.right)
+}
+
+// notExpr negates a boolean operand.
+type notExpr struct {
+	operand Expr
+}
+
+func (e notExpr) eval(df *DataFrame) (column, error) {
+	operand, err := e.operand.eval(df)
+	if err != nil {
+		return nil, err
+	}
+	if !isBoolean(operand.dtype()) {
+		return nil, fmt.Errorf("evaluating %s: %w: operand is %s, expected bool", e, ErrTypeMismatch, operand.dtype())
+	}
+
+	data := boolValues(operand)
+	result := make([]bool, len(data))
+	for i, v := range data {
+		result[i] = !v
+	}
+	return &typedColumn[bool]{dt: Bool, data: result, valid: validity(operand)}, nil
+}
+
+func (e notExpr) String() string {
+	return fmt.Sprintf("(NOT %s)", e.operand)
+}
+
+// nullCheckExpr checks whether its operand is null.
+type nullCheckExpr struct {
+	operand Expr
+	not     bool
+}
+
+func (e nullCheckExpr) eval(df *DataFrame) (column, error) {
+	operand, err := e.operand.eval(df)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]bool, operand.len())
+	for i := range result {
+		result[i] = operand.isNull(i) != e.not
+	}
+	return &typedColumn[bool]{dt: Bool, data: result}, nil
+}
+
+func (e nullCheckExpr) String() string {
+	if e.not {
+		return fmt.Sprintf("(%s IS NOT NULL)", e.operand)
+	}
+	return fmt.Sprintf("(%s IS NULL)", e.operand)
+}
+
+// aliasExpr names the result of its operand.
+type aliasExpr struct {
+	operand Expr
+	name    string
+}
+
+func (e aliasExpr) eval(df *DataFrame) (column, error) {
+	return e.operand.eval(df)
+}
+
+func (e aliasExpr) String() string {
+	return fmt.Sprintf("%s AS %s", e.operand, e.name)
+}
+
+// caseBranch is a branch of a caseExpr.
+type caseBranch struct {
+	condition, value Expr
+}
+
+// caseExpr picks the value of the first branch whose condition is true.
+type caseExpr struct {
+	branches  []caseBranch
+	otherwise *Expr
+}
+
+func (e caseExpr) eval(df *DataFrame) (column, error) {
+	conditions := make([]column, len(e.branches))
+	choices := make([]column, 0, len(e.branches)+1)
+	for i, b := range e.branches {
+		condition, value, err := evalOperands(df, b.condition, b.value)
+		if err != nil {
+			return nil, err
+		}
+		if !isBoolean(condition.dtype()) {
+			return nil, fmt.Errorf("evaluating %s: %w: condition %s is %s, expected bool",
+				e, ErrTypeMismatch, b.condition, condition.dtype())
+		}
+		if conditions[i], err = castColumn(condition, Bool); err != nil {
+			return nil, err
+		}
+		choices = append(choices, value)
+	}
+	if e.otherwise != nil {
+		value, err := e.otherwise.eval(df)
+		if err != nil {
+			return nil, err
+		}
+		choices = append(choices, value)
+	}
+
+	dt := Null
+	for _, c := range choices {
+		var ok bool
+		if dt, ok = commonType(dt, c.dtype()); !ok {
+			return nil, fmt.Errorf("evaluating %s: %w: values are %s and %s", e, ErrTypeMismatch, dt, c.dtype())
+		}
+	}
+	for i, c := range choices {
+		var err error
+		if choices[i], err = castColumn(c, dt); err != nil {
+			return nil, err
+		}
+	}
+
+	// Pick the first branch whose condition is true, the default, or null (-1).
+	picks := make([]int, df.rows)
+	for row := range picks {
+		picks[row] = -1
+		if e.otherwise != nil {
+			picks[row] = len(e.branches)
+		}
+		for i, condition := range conditions {
+			if !condition.isNull(row) && typedValues[bool](condition)[row] {
+				picks[row] = i
+				break
+			}
+		}
+	}
+
+	switch dt {
+	case Int64:
+		return pick[int64](dt, choices, picks), nil
+	case Float64:
+		return pick[float64](dt, choices, picks), nil
+	case String:
+		return pick[string](dt, choices, picks), nil
+	case Bool:
+		return pick[bool](dt, choices, picks), nil
+	default:
+		return &nullColumn{n: df.rows}, nil
+	}
+}
+
+func (e caseExpr) String() string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for _, branch := range e.branches {
+		fmt.Fprintf(&b, " WHEN %s THEN %s", branch.condition, branch.value)
+	}
+	if e.otherwise != nil {
+		fmt.Fprintf(&b, " ELSE %s", e.otherwise)
+	}
+	b.WriteString(" END")
+	return b.String()
+}
+
+// commonType returns the data type that values of types a and b can both
+// be stored as, and false if there is none.
+func commonType(a, b DType) (DType, bool) {
+	switch {
+	case a == b && a != Any:
+		return a, true
+	case a == Null:
+		return b, b != Any
+	case b == Null:
+		return a, a != Any
+	case isNumeric(a) && isNumeric(b):
+		return Float64, true
+	default:
+		return a, false
+	}
+}
+
+// pick returns a column whose i-th value is the i-th value of
+// choices[picks[i]], or null if picks[i] is -1.
+func pick[T any](dt DType, choices []column, picks []int) column {
+	result := &typedColumn[T]{dt: dt, data: make([]T, len(picks)), valid: newBitmap(len(picks))}
+	for i, p := range picks {
+		if p == -1 || choices[p].isNull(i) {
+			continue
+		}
+		result.data[i] = typedValues[T](choices[p])[i]
+		result.valid.set(i)
+	}
+	return result
+}
+
+// invalidExpr is an expression that was built incorrectly.
+type invalidExpr struct {
+	desc string
+	err  error
+}
+
+func (e invalidExpr) eval(*DataFrame) (column, error) {
+	return nil, fmt.Errorf("evaluating %s: %w", e.desc, e.err)
+}
+
+func (e invalidExpr) String() string { return e.desc }
+
+// evalOperands evaluates the operands of a binary expression.
+func evalOperands(df *DataFrame, left, right Expr) (column, column, error) {
+	l, err := left.eval(df)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := right.eval(df)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, r, nil
+}
+
+// isNumeric reports whether dt is a numeric data type.
+func isNumeric(dt DType) bool {
+	return dt == Int64 || dt == Float64
+}
+
+// isBoolean reports whether columns of type dt can be used as booleans.
+func isBoolean(dt DType) bool {
+	return dt == Bool || dt == Null
+}
+
+// typedValues returns the values of the typed column c, with zero values for nulls.
+func typedValues[T any](c column) []T {
+	return c.(*typedColumn[T]).data
+}
+
+// floats returns the values of the numeric column c as float64.
+func floats(c column) []float64 {
+	if c.dtype() == Float64 {
+		return typedValues[float64](c)
+	}
+	ints := typedValues[int64](c)
+	result := make([]float64, len(ints))
+	for i, v := range ints {
+		result[i] = float64(v)
+	}
+	return result
+}
+
+// boolInts returns the values of the Bool column c as 0 for false and 1 for true.
+func boolInts(c column) []int {
+	bools := typedValues[bool](c)
+	result := make([]int, len(bools))
+	for i, v := range bools {
+		if v {
+			result[i] = 1
+		}
+	}
+	return result
+}
+
+// boolValues returns the values of c, a Bool or Null column.
+func boolValues(c column) []bool {
+	if c.dtype() == Null {
+		return make([]bool, c.len())
+	}
+	return typedValues[bool](c)
+}
+
+// nullBools returns a Bool column of n null values.
+func nullBools(n int) column {
+	return &typedColumn[bool]{dt: Bool, data: make([]bool, n), valid: newBitmap(n)}
+}
+
+// validity returns the validity bitmap of c, nil if it has no nulls.
+func validity(c column) bitmap {
+	switch c := c.(type) {
+	case *nullColumn:
+		return newBitmap(c.n)
+	default:
+		if c.nullCount() == 0 {
+			return nil
+		}
+		valid := newBitmap(c.len())
+		for i := range c.len() {
+			if !c.isNull(i) {
+				valid.set(i)
+			}
+		}
+		return valid
+	}
+}
+
+// andValidity returns the bitmap of the rows valid in both a and b.
+func andValidity(a, b column) bitmap {
+	if a.nullCount() == 0 && b.nullCount() == 0 {
+		return nil
+	}
+	valid := newBitmap(a.len())
+	for i := range a.len() {
+		if !a.isNull(i) && !b.isNull(i) {
+			valid.set(i)
+		}
+	}
+	return valid
+}
@@ -0,0 +1,400 @@
+package dataframe_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"mkubasz/quanto/internal/dataframe"
+)
+
+// TestSelectExpr verifies computing columns from expressions.
+func TestSelectExpr(t *testing.T) {
+	col, lit := dataframe.Col, dataframe.Lit
+
+	tests := []struct {
+		name      string
+		expr      dataframe.Expr
+		wantName  string
+		wantType  dataframe.DType
+		wantValue []interface{}
+	}{
+		{
+			name:      "column",
+			expr:      col("product"),
+			wantName:  "product",
+			wantType:  dataframe.String,
+			wantValue: []interface{}{"apple", "pear", "plum", "fig"},
+		},
+		{
+			name:      "integer addition",
+			expr:      col("quantity").Add(lit(1)),
+			wantName:  "(quantity + 1)",
+			wantType:  dataframe.Int64,
+			wantValue: []interface{}{int64(4), int64(8), nil, int64(5)},
+		},
+		{
+			name:      "subtraction",
+			expr:      col("quantity").Sub(lit(10)),
+			wantName:  "(quantity - 10)",
+			wantType:  dataframe.Int64,
+			wantValue: []interface{}{int64(-7), int64(-3), nil, int64(-6)},
+		},
+		{
+			name:      "mixed multiplication with alias",
+			expr:      col("quantity").Mul(col("price")).Alias("total"),
+			wantName:  "total",
+			wantType:  dataframe.Float64,
+			wantValue: []interface{}{1.5, 14.0, nil, 1.0},
+		},
+		{
+			name:      "integer division is float",
+			expr:      col("quantity").Div(lit(2)),
+			wantName:  "(quantity / 2)",
+			wantType:  dataframe.Float64,
+			wantValue: []interface{}{1.5, 3.5, nil, 2.0},
+		},
+		{
+			name:      "division by zero",
+			expr:      col("price").Div(lit(0)),
+			wantName:  "(price / 0)",
+			wantType:  dataframe.Float64,
+			wantValue: []interface{}{nil, nil, nil, nil},
+		},
+		{
+			name:      "modulo",
+			expr:      col("quantity").Mod(lit(3)),
+			wantName:  "(quantity % 3)",
+			wantType:  dataframe.Int64,
+			wantValue: []interface{}{int64(0), int64(1), nil, int64(1)},
+		},
+		{
+			name:      "float modulo",
+			expr:      col("price").Mod(lit(1)),
+			wantName:  "(price % 1)",
+			wantType:  dataframe.Float64,
+			wantValue: []interface{}{0.5, 0.0, 0.5, 0.25},
+		},
+		{
+			name:      "comparison",
+			expr:      col("price").Ge(lit(1.5)),
+			wantName:  "(price >= 1.5)",
+			wantType:  dataframe.Bool,
+			wantValue: []interface{}{false, true, true, false},
+		},
+		{
+			name:      "cast float to int",
+			expr:      col("price").Cast(dataframe.Int64),
+			wantName:  "CAST(price AS int64)",
+			wantType:  dataframe.Int64,
+			wantValue: []interface{}{int64(0), int64(2), int64(1), int64(0)},
+		},
+		{
+			name:      "cast int to string",
+			expr:      col("quantity").Cast(dataframe.String),
+			wantName:  "CAST(quantity AS string)",
+			wantType:  dataframe.String,
+			wantValue: []interface{}{"3", "7", nil, "4"},
+		},
+		{
+			name:      "cast string to int",
+			expr:      lit("12").Cast(dataframe.Int64).Add(col("quantity").Cast(dataframe.String).Cast(dataframe.Int64)),
+			wantName:  "(CAST(12 AS int64) + CAST(CAST(quantity AS string) AS int64))",
+			wantType:  dataframe.Int64,
+			wantValue: []interface{}{int64(15), int64(19), nil, int64(16)},
+		},
+		{
+			name:      "cast unparsable string",
+			expr:      col("product").Cast(dataframe.Float64),
+			wantName:  "CAST(product AS float64)",
+			wantType:  dataframe.Float64,
+			wantValue: []interface{}{nil, nil, nil, nil},
+		},
+		{
+			name:      "cast bool to int",
+			expr:      col("country").Eq(lit("PL")).Cast(dataframe.Int64),
+			wantName:  "CAST((country = PL) AS int64)",
+			wantType:  dataframe.Int64,
+			wantValue: []interface{}{int64(1), int64(0), int64(1), int64(0)},
+		},
+		{
+			name: "when otherwise",
+			expr: dataframe.When(col("quantity").Gt(lit(5)), lit("many")).
+				When(col("quantity").Gt(lit(3)), lit("some")).
+				Otherwise(lit("few")),
+			wantName:  "CASE WHEN (quantity > 5) THEN many WHEN (quantity > 3) THEN some ELSE few END",
+			wantType:  dataframe.String,
+			wantValue: []interface{}{"few", "many", "few", "some"},
+		},
+		{
+			name:      "when without otherwise",
+			expr:      dataframe.When(col("country").Eq(lit("PL")), col("quantity")).Alias("pl_quantity"),
+			wantName:  "pl_quantity",
+			wantType:  dataframe.Int64,
+			wantValue: []interface{}{int64(3), nil, nil, nil},
+		},
+		{
+			name:      "when widens integers",
+			expr:      dataframe.When(col("quantity").IsNull(), lit(0)).Otherwise(col("price")).Alias("value"),
+			wantName:  "value",
+			wantType:  dataframe.Float64,
+			wantValue: []interface{}{0.5, 2.0, 0.0, 0.25},
+		},
+	}
+
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"apple", "pear", "plum", "fig"},
+			[]interface{}{3, 7, nil, 4},
+			[]interface{}{0.5, 2.0, 1.5, 0.25},
+			[]interface{}{"PL", "DE", "PL", "DE"},
+		},
+		[]string{"product", "quantity", "price", "country"},
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := df.SelectExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			field := result.Schema().Fields[0]
+			if field.Name != tt.wantName || field.DType != tt.wantType {
+				t.Errorf("field = %s: %s, want %s: %s", field.Name, field.DType, tt.wantName, tt.wantType)
+			}
+			series, _ := result.Select(field.Name)
+			if !slices.Equal(series.Data, tt.wantValue) {
+				t.Errorf("values = %v, want %v", series.Data, tt.wantValue)
+			}
+		})
+	}
+}
+
+// TestSelectExprErrors verifies invalid expressions.
+func TestSelectExprErrors(t *testing.T) {
+	col, lit := dataframe.Col, dataframe.Lit
+
+	tests := []struct {
+		name    string
+		exprs   []dataframe.Expr
+		wantErr error
+	}{
+		{
+			name:    "no expressions",
+			wantErr: dataframe.ErrInvalidData,
+		},
+		{
+			name:    "missing column",
+			exprs:   []dataframe.Expr{col("missing").Add(lit(1))},
+			wantErr: dataframe.ErrColumnNotFound,
+		},
+		{
+			name:    "arithmetic on strings",
+			exprs:   []dataframe.Expr{col("product").Add(lit(1))},
+			wantErr: dataframe.ErrTypeMismatch,
+		},
+		{
+			name:    "cast to any",
+			exprs:   []dataframe.Expr{col("price").Cast(dataframe.Any)},
+			wantErr: dataframe.ErrTypeMismatch,
+		},
+		{
+			name:    "when with mismatched values",
+			exprs:   []dataframe.Expr{dataframe.When(col("price").Gt(lit(1)), lit("high")).Otherwise(lit(0))},
+			wantErr: dataframe.ErrTypeMismatch,
+		},
+		{
+			name:    "when with non-boolean condition",
+			exprs:   []dataframe.Expr{dataframe.When(col("price"), lit(1))},
+			wantErr: dataframe.ErrTypeMismatch,
+		},
+		{
+			name:    "otherwise without when",
+			exprs:   []dataframe.Expr{col("price").Otherwise(lit(1))},
+			wantErr: dataframe.ErrInvalidData,
+		},
+		{
+			name:    "aggregate outside of agg",
+			exprs:   []dataframe.Expr{col("price").Sum()},
+			wantErr: dataframe.ErrInvalidData,
+		},
+	}
+
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"apple", "pear", "plum", "fig"},
+			[]interface{}{3, 7, nil, 4},
+			[]interface{}{0.5, 2.0, 1.5, 0.25},
+			[]interface{}{"PL", "DE", "PL", "DE"},
+		},
+		[]string{"product", "quantity", "price", "country"},
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := df.SelectExpr(tt.exprs...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestWithColumn verifies adding and replacing columns.
+func TestWithColumn(t *testing.T) {
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"apple", "pear", "plum", "fig"},
+			[]interface{}{3, 7, nil, 4},
+			[]interface{}{0.5, 2.0, 1.5, 0.25},
+			[]interface{}{"PL", "DE", "PL", "DE"},
+		},
+		[]string{"product", "quantity", "price", "country"},
+	)
+
+	added, err := df.WithColumn("total", dataframe.Col("quantity").Mul(dataframe.Col("price")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := added.Columns(); !slices.Equal(got, []string{"product", "quantity", "price", "country", "total"}) {
+		t.Errorf("columns = %v", got)
+	}
+
+	replaced, err := added.WithColumn("price", dataframe.Col("price").Mul(dataframe.Lit(2)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replaced.NumColumns() != 5 {
+		t.Errorf("columns = %d, want 5", replaced.NumColumns())
+	}
+	prices, _ := replaced.Select("price")
+	if want := []interface{}{1.0, 4.0, 3.0, 0.5}; !slices.Equal(prices.Data, want) {
+		t.Errorf("prices = %v, want %v", prices.Data, want)
+	}
+
+	// The original DataFrame is unchanged.
+	if df.NumColumns() != 4 {
+		t.Error("DataFrame was mutated, expected immutability")
+	}
+	original, _ := df.Select("price")
+	if want := []interface{}{0.5, 2.0, 1.5, 0.25}; !slices.Equal(original.Data, want) {
+		t.Errorf("prices = %v, want %v", original.Data, want)
+	}
+
+	if _, err := df.WithColumn(" ", dataframe.Lit(1)); !errors.Is(err, dataframe.ErrInvalidColumnName) {
+		t.Errorf("expected ErrInvalidColumnName, got %v", err)
+	}
+}
+
+// TestFilterWithArithmetic verifies filtering by a computed expression.
+func TestFilterWithArithmetic(t *testing.T) {
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"apple", "pear", "plum", "fig"},
+			[]interface{}{3, 7, nil, 4},
+			[]interface{}{0.5, 2.0, 1.5, 0.25},
+			[]interface{}{"PL", "DE", "PL", "DE"},
+		},
+		[]string{"product", "quantity", "price", "country"},
+	)
+
+	result, err := df.Filter(context.Background(), dataframe.Col("quantity").Mul(dataframe.Col("price")).Gt(dataframe.Lit(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	products, _ := result.Select("product")
+	if want := []interface{}{"apple", "pear"}; !slices.Equal(products.Data, want) {
+		t.Errorf("products = %v, want %v", products.Data, want)
+	}
+}
+
+// TestAgg verifies aggregating a whole DataFrame.
+func TestAgg(t *testing.T) {
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"apple", "pear", "plum", "fig"},
+			[]interface{}{3, 7, nil, 4},
+			[]interface{}{0.5, 2.0, 1.5, 0.25},
+			[]interface{}{"PL", "DE", "PL", "DE"},
+		},
+		[]string{"product", "quantity", "price", "country"},
+	)
+	col := dataframe.Col
+
+	result, err := df.Agg(
+		col("quantity").Sum(),
+		col("quantity").Count().Alias("n"),
+		col("price").Mean(),
+		col("price").Min(),
+		col("product").Max(),
+		col("quantity").Mul(col("price")).Sum().Alias("revenue"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantColumns := []string{"sum(quantity)", "n", "mean(price)", "min(price)", "max(product)", "revenue"}
+	if got := result.Columns(); !slices.Equal(got, wantColumns) {
+		t.Errorf("columns = %v, want %v", got, wantColumns)
+	}
+	want := []interface{}{int64(14), int64(3), 1.0625, 0.25, "plum", 16.5}
+	for _, row := range result.Rows() {
+		if got := row.Values(); !slices.Equal(got, want) {
+			t.Errorf("row = %v, want %v", got, want)
+		}
+	}
+
+	if _, err := df.Agg(col("price")); !errors.Is(err, dataframe.ErrInvalidData) {
+		t.Errorf("expected ErrInvalidData, got %v", err)
+	}
+	if _, err := df.Agg(col("product").Sum()); !errors.Is(err, dataframe.ErrTypeMismatch) {
+		t.Errorf("expected ErrTypeMismatch, got %v", err)
+	}
+}
+
+// TestGroupByAggExpr verifies aggregate expressions over groups.
+func TestGroupByAggExpr(t *testing.T) {
+	ctx := context.Background()
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"apple", "pear", "plum", "fig"},
+			[]interface{}{3, 7, nil, 4},
+			[]interface{}{0.5, 2.0, 1.5, 0.25},
+			[]interface{}{"PL", "DE", "PL", "DE"},
+		},
+		[]string{"product", "quantity", "price", "country"},
+	)
+
+	grouped, err := df.GroupBy(ctx, "country")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	result, err := grouped.Agg(dataframe.Count).
+		AggExpr(dataframe.Col("quantity").Sum().Alias("quantity"), dataframe.Col("price").Max()).
+		Show(ctx)
+	if err != nil {
+		t.Fatalf("Show failed: %v", err)
+	}
+
+	wantColumns := []string{"country", "count", "quantity", "max(price)"}
+	if got := result.Columns(); !slices.Equal(got, wantColumns) {
+		t.Errorf("columns = %v, want %v", got, wantColumns)
+	}
+	want := [][]interface{}{
+		{"PL", int64(2), int64(3), 1.5},
+		{"DE", int64(2), int64(11), 2.0},
+	}
+	for i, row := range result.Rows() {
+		if got := row.Values(); !slices.Equal(got, want[i]) {
+			t.Errorf("row %d = %v, want %v", i, got, want[i])
+		}
+	}
+
+	grouped, _ = df.GroupBy(ctx, "country")
+	if _, err := grouped.AggExpr(dataframe.Col("missing").Sum()).Show(ctx); !errors.Is(err, dataframe.ErrColumnNotFound) {
+		t.Errorf("expected ErrColumnNotFound, got %v", err)
+	}
+}
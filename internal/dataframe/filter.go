@@ -0,0 +1,132 @@
+package dataframe
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// minChunkRows is the minimum number of rows evaluated by a single worker.
+const minChunkRows = 1024
+
+// Filter returns a new DataFrame with the rows for which the boolean
+// condition is true, such as Col("age").Gt(Lit(30)). Rows where the
+// condition is false or null are dropped, and the remaining rows keep
+// their order. The condition is evaluated in parallel over chunks of rows.
+//
+// Returns ErrColumnNotFound if the condition refers to a missing column.
+// Returns ErrTypeMismatch if the condition isn't boolean or compares incompatible types.
+func (df *DataFrame) Filter(ctx context.Context, condition Expr) (*DataFrame, error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	chunks := df.chunks()
+	kept := make([][]int, len(chunks))
+	err := parallel(ctx, len(chunks), func(i int) error {
+		lo, hi := chunks[i][0], chunks[i][1]
+		mask, err := condition.eval(df.slice(lo, hi))
+		if err != nil {
+			return err
+		}
+		if !isBoolean(mask.dtype()) {
+			return fmt.Errorf("%w: condition is %s, expected bool", ErrTypeMismatch, mask.dtype())
+		}
+
+		data := boolValues(mask)
+		for j, keep := range data {
+			if keep && !mask.isNull(j) {
+				kept[i] = append(kept[i], lo+j)
+			}
+		}
+		return nil
+	})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("filtering by %s: %w", condition, err)
+	}
+
+	return df.take(slices.Concat(kept...)), nil
+}
+
+// Where is an alias for Filter.
+func (df *DataFrame) Where(ctx context.Context, condition Expr) (*DataFrame, error) {
+	return df.Filter(ctx, condition)
+}
+
+// chunks splits the rows of the DataFrame into ranges of at least
+// minChunkRows rows, one per CPU, as [lo, hi) pairs. A DataFrame without
+// rows has a single empty range, so that expressions are still checked.
+func (df *DataFrame) chunks() [][2]int {
+	if df.rows == 0 {
+		return [][2]int{{0, 0}}
+	}
+
+	size := max(minChunkRows, (df.rows+runtime.NumCPU()-1)/runtime.NumCPU())
+	var chunks [][2]int
+	for lo := 0; lo < df.rows; lo += size {
+		chunks = append(chunks, [2]int{lo, min(lo+size, df.rows)})
+	}
+	return chunks
+}
+
+// slice returns a DataFrame with the rows from lo up to hi, sharing the
+// storage of df.
+func (df *DataFrame) slice(lo, hi int) *DataFrame {
+	cols := make([]column, len(df.cols))
+	for i, col := range df.cols {
+		cols[i] = col.slice(lo, hi)
+	}
+	return df.withColumns(cols)
+}
+
+// parallel calls fn for each of n chunks on up to runtime.NumCPU() workers
+// and returns the first error. Chunks not yet started are skipped after an
+// error or once ctx is canceled.
+func parallel(ctx context.Context, n int, fn func(i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	jobs := make(chan int)
+	for range min(n, runtime.NumCPU()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := fn(i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	for i := range n {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,322 @@
+package dataframe_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"mkubasz/quanto/internal/dataframe"
+)
+
+// TestFilter verifies filtering rows by a boolean expression.
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition dataframe.Expr
+		wantNames []interface{}
+	}{
+		{
+			name:      "comparison with literal",
+			condition: dataframe.Col("age").Gt(dataframe.Lit(30)),
+			wantNames: []interface{}{"Bob", "Cid", "Eve"},
+		},
+		{
+			name:      "and",
+			condition: dataframe.Col("age").Gt(dataframe.Lit(30)).And(dataframe.Col("country").Eq(dataframe.Lit("PL"))),
+			wantNames: []interface{}{"Bob"},
+		},
+		{
+			name:      "or",
+			condition: dataframe.Col("age").Lt(dataframe.Lit(30)).Or(dataframe.Col("country").Ne(dataframe.Lit("PL"))),
+			wantNames: []interface{}{"Ann", "Cid"},
+		},
+		{
+			name:      "or with null operand",
+			condition: dataframe.Col("country").Eq(dataframe.Lit("DE")).Or(dataframe.Col("age").Ge(dataframe.Lit(31))),
+			wantNames: []interface{}{"Bob", "Cid", "Eve"},
+		},
+		{
+			name:      "not",
+			condition: dataframe.Col("country").Eq(dataframe.Lit("PL")).Not(),
+			wantNames: []interface{}{"Cid"},
+		},
+		{
+			name:      "int column with float literal",
+			condition: dataframe.Col("age").Le(dataframe.Lit(35.0)),
+			wantNames: []interface{}{"Ann", "Bob", "Eve"},
+		},
+		{
+			name:      "compare two columns",
+			condition: dataframe.Col("score").Lt(dataframe.Col("age")),
+			wantNames: []interface{}{"Ann", "Bob", "Cid", "Eve"},
+		},
+		{
+			name:      "is null",
+			condition: dataframe.Col("age").IsNull().Or(dataframe.Col("country").IsNull()),
+			wantNames: []interface{}{"Dan", "Eve"},
+		},
+		{
+			name:      "is not null",
+			condition: dataframe.Col("country").IsNotNull(),
+			wantNames: []interface{}{"Ann", "Bob", "Cid", "Dan"},
+		},
+		{
+			name:      "null literal",
+			condition: dataframe.Col("age").Eq(dataframe.Lit(nil)),
+			wantNames: nil,
+		},
+	}
+
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"Ann", "Bob", "Cid", "Dan", "Eve"},
+			[]interface{}{25, 35, 45, nil, 31},
+			[]interface{}{"PL", "PL", "DE", "PL", nil},
+			[]interface{}{1.5, 2.5, 3.5, 4.5, 5.5},
+		},
+		[]string{"name", "age", "country", "score"},
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := df.Filter(context.Background(), tt.condition)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			names, _ := result.Select("name")
+			if !slices.Equal(names.Data, tt.wantNames) {
+				t.Errorf("names = %v, want %v", names.Data, tt.wantNames)
+			}
+			if result.NumColumns() != df.NumColumns() {
+				t.Errorf("columns = %d, want %d", result.NumColumns(), df.NumColumns())
+			}
+		})
+	}
+}
+
+// TestFilterKeepsColumnsAligned verifies that every column is filtered consistently.
+func TestFilterKeepsColumnsAligned(t *testing.T) {
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"Ann", "Bob", "Cid", "Dan", "Eve"},
+			[]interface{}{25, 35, 45, nil, 31},
+			[]interface{}{"PL", "PL", "DE", "PL", nil},
+			[]interface{}{1.5, 2.5, 3.5, 4.5, 5.5},
+		},
+		[]string{"name", "age", "country", "score"},
+	)
+
+	result, err := df.Where(context.Background(), dataframe.Col("country").Eq(dataframe.Lit("PL")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]interface{}{
+		{"Ann", int64(25), "PL", 1.5},
+		{"Bob", int64(35), "PL", 2.5},
+		{"Dan", nil, "PL", 4.5},
+	}
+	if result.NumRows() != len(want) {
+		t.Fatalf("rows = %d, want %d", result.NumRows(), len(want))
+	}
+	for i, row := range result.Rows() {
+		if got := row.Values(); !slices.Equal(got, want[i]) {
+			t.Errorf("row %d = %v, want %v", i, got, want[i])
+		}
+	}
+	if df.NumRows() != 5 {
+		t.Error("DataFrame was mutated, expected immutability")
+	}
+}
+
+// TestFilterErrors verifies invalid filter conditions.
+func TestFilterErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition dataframe.Expr
+		wantErr   error
+	}{
+		{
+			name:      "missing column",
+			condition: dataframe.Col("missing").Gt(dataframe.Lit(1)),
+			wantErr:   dataframe.ErrColumnNotFound,
+		},
+		{
+			name:      "non-boolean condition",
+			condition: dataframe.Col("age"),
+			wantErr:   dataframe.ErrTypeMismatch,
+		},
+		{
+			name:      "incompatible comparison",
+			condition: dataframe.Col("age").Eq(dataframe.Lit("PL")),
+			wantErr:   dataframe.ErrTypeMismatch,
+		},
+		{
+			name:      "and with non-boolean operand",
+			condition: dataframe.Col("age").And(dataframe.Lit(true)),
+			wantErr:   dataframe.ErrTypeMismatch,
+		},
+		{
+			name:      "empty expression",
+			condition: dataframe.Expr{},
+			wantErr:   dataframe.ErrInvalidData,
+		},
+	}
+
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"Ann", "Bob", "Cid", "Dan", "Eve"},
+			[]interface{}{25, 35, 45, nil, 31},
+			[]interface{}{"PL", "PL", "DE", "PL", nil},
+			[]interface{}{1.5, 2.5, 3.5, 4.5, 5.5},
+		},
+		[]string{"name", "age", "country", "score"},
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := df.Filter(context.Background(), tt.condition)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestFilterNaN verifies that comparisons with NaN follow Go's operators.
+func TestFilterNaN(t *testing.T) {
+	col, lit := dataframe.Col, dataframe.Lit
+	nan := math.NaN()
+
+	tests := []struct {
+		name      string
+		condition dataframe.Expr
+		wantIDs   []interface{}
+	}{
+		{name: "less than", condition: col("f").Lt(lit(1.5)), wantIDs: []interface{}{"b"}},
+		{name: "greater or equal", condition: col("f").Ge(lit(1.5)), wantIDs: []interface{}{"c"}},
+		{name: "equal to itself", condition: col("f").Eq(col("f")), wantIDs: []interface{}{"b", "c"}},
+		{name: "not equal to itself", condition: col("f").Ne(col("f")), wantIDs: []interface{}{"a"}},
+		{name: "equal to NaN", condition: col("f").Eq(lit(nan)), wantIDs: []interface{}{}},
+		{name: "not equal to NaN", condition: col("f").Ne(lit(nan)), wantIDs: []interface{}{"a", "b", "c"}},
+	}
+
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"a", "b", "c"},
+			[]interface{}{nan, 1.0, 2.0},
+		},
+		[]string{"id", "f"},
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := df.Filter(context.Background(), tt.condition)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ids, _ := result.Select("id")
+			if !slices.Equal(ids.Data, tt.wantIDs) {
+				t.Errorf("ids = %v, want %v", ids.Data, tt.wantIDs)
+			}
+		})
+	}
+}
+
+// TestFilterEmpty verifies that conditions are checked on a DataFrame
+// without rows.
+func TestFilterEmpty(t *testing.T) {
+	ctx := context.Background()
+	df := mustNew(t, []interface{}{[]string{}, []int64{}}, []string{"name", "age"})
+
+	result, err := df.Filter(ctx, dataframe.Col("age").Gt(dataframe.Lit(30)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.NumRows() != 0 {
+		t.Errorf("rows = %d, want 0", result.NumRows())
+	}
+
+	if _, err := df.Filter(ctx, dataframe.Col("missing").Gt(dataframe.Lit(1))); !errors.Is(err, dataframe.ErrColumnNotFound) {
+		t.Errorf("expected ErrColumnNotFound, got %v", err)
+	}
+	if _, err := df.Filter(ctx, dataframe.Col("age")); !errors.Is(err, dataframe.ErrTypeMismatch) {
+		t.Errorf("expected ErrTypeMismatch, got %v", err)
+	}
+}
+
+// TestFilterParallel verifies filtering a DataFrame split into many chunks.
+func TestFilterParallel(t *testing.T) {
+	const n = 100000
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = int64(i)
+	}
+	df := mustNew(t, []interface{}{ids}, []string{"id"})
+
+	result, err := df.Filter(context.Background(), dataframe.Col("id").Ge(dataframe.Lit(n/2)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	series, _ := result.Select("id")
+	if len(series.Data) != n/2 {
+		t.Fatalf("rows = %d, want %d", len(series.Data), n/2)
+	}
+	for i, v := range series.Data {
+		if v != int64(n/2+i) {
+			t.Fatalf("row %d = %v, want %d", i, v, n/2+i)
+		}
+	}
+}
+
+// TestFilterCancellation verifies context cancellation.
+func TestFilterCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"Ann", "Bob", "Cid", "Dan", "Eve"},
+			[]interface{}{25, 35, 45, nil, 31},
+			[]interface{}{"PL", "PL", "DE", "PL", nil},
+			[]interface{}{1.5, 2.5, 3.5, 4.5, 5.5},
+		},
+		[]string{"name", "age", "country", "score"},
+	)
+	_, err := df.Filter(ctx, dataframe.Col("age").Gt(dataframe.Lit(30)))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got: %v", err)
+	}
+}
+
+// TestExprString verifies the description of expressions.
+func TestExprString(t *testing.T) {
+	expr := dataframe.Col("age").Gt(dataframe.Lit(30)).And(dataframe.Col("country").IsNotNull().Not())
+	want := "((age > 30) AND (NOT (country IS NOT NULL)))"
+	if got := expr.String(); got != want {
+		t.Errorf("expr = %s, want %s", got, want)
+	}
+}
+
+// BenchmarkFilter benchmarks filtering rows.
+func BenchmarkFilter(b *testing.B) {
+	ctx := context.Background()
+
+	data := make([]int64, 100000)
+	for i := range data {
+		data[i] = int64(i % 100)
+	}
+	df, _ := dataframe.New([]interface{}{data}, []string{"value"})
+	condition := dataframe.Col("value").Lt(dataframe.Lit(50))
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = df.Filter(ctx, condition)
+	}
+}
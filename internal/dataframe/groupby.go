@@ -3,6 +3,9 @@ package dataframe
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
 )
 
 // GroupBy represents a grouped DataFrame for aggregation operations.
@@ -10,11 +13,15 @@ type GroupBy struct {
 	df         *DataFrame
 	columnName string
 	aggs       []func([]interface{}) int
+	exprs      []Expr
+	keys       []interface{}
+	indexes    [][]int
 	groups     map[interface{}][]interface{}
 }
 
 // GroupBy creates a grouped DataFrame based on the specified column.
 // Subsequent aggregation operations can be performed on the groups.
+// Null values are grouped together under a nil key.
 //
 // Returns ErrColumnNotFound if the column doesn't exist.
 // Returns ErrInvalidColumnName if the column name is empty.
@@ -34,21 +41,36 @@ func (df *DataFrame) GroupBy(ctx context.Context, name string) (*GroupBy, error)
 		return nil, fmt.Errorf("grouping by column %s: %w", name, err)
 	}
 
-	// Create groups based on unique values in the column
+	// Create groups based on unique values in the column, in order of first appearance
+	var (
+		keys    []interface{}
+		indexes [][]int
+	)
+	positions := make(map[interface{}]int)
 	groups := make(map[interface{}][]interface{})
-	for _, el := range df.series[index].Data {
+	for i, el := range boxValues(df.cols[index]) {
 		// Check context periodically
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
 		}
+		pos, ok := positions[el]
+		if !ok {
+			pos = len(keys)
+			positions[el] = pos
+			keys = append(keys, el)
+			indexes = append(indexes, nil)
+		}
+		indexes[pos] = append(indexes[pos], i)
 		groups[el] = append(groups[el], el)
 	}
 
 	return &GroupBy{
 		df:         df,
 		columnName: name,
+		keys:       keys,
+		indexes:    indexes,
 		groups:     groups,
 	}, nil
 }
@@ -61,52 +83,85 @@ func (dfg *GroupBy) Agg(f func([]interface{}) int) *GroupBy {
 	return dfg
 }
 
+// AggExpr adds aggregate expressions, such as Col("price").Sum(), to be
+// evaluated over the rows of each group. Their results follow the results
+// of the aggregation functions added with Agg, in columns named after the
+// aliases or descriptions of the expressions.
+func (dfg *GroupBy) AggExpr(exprs ...Expr) *GroupBy {
+	dfg.exprs = append(dfg.exprs, exprs...)
+	return dfg
+}
+
 // Show materializes the grouped DataFrame with aggregations applied.
-// Returns a new DataFrame with columns for the grouping key and aggregation results.
+// Returns a new DataFrame with one row per group, in order of first appearance,
+// and columns for the grouping key and each aggregation result. Aggregation
+// columns are named after their function, e.g. "count" for Count, or "aggN"
+// for the N-th aggregation when the function has no name.
 //
 // Returns ErrInvalidData if Show is called before any aggregations are added.
+// Returns ErrColumnNotFound if an aggregate expression refers to a missing column.
+// Returns ErrTypeMismatch if an aggregate expression can't aggregate the values of its column.
 func (dfg *GroupBy) Show(ctx context.Context) (*DataFrame, error) {
 	// Check context
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	if len(dfg.aggs) == 0 {
+	if len(dfg.aggs) == 0 && len(dfg.exprs) == 0 {
 		return nil, fmt.Errorf("showing grouped data: %w: no aggregation functions specified", ErrInvalidData)
 	}
 
-	hash := make([]interface{}, 0)
-	groups := make([]interface{}, 0)
-
-	for key, group := range dfg.groups {
-		// Check context periodically
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
+	data := make([]interface{}, len(dfg.aggs)+1)
+	data[0] = dfg.keys
+	columns := []string{dfg.columnName}
+	for i, agg := range dfg.aggs {
+		results := make([]int64, 0, len(dfg.keys))
+		for _, key := range dfg.keys {
+			// Check context periodically
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			results = append(results, int64(agg(dfg.groups[key])))
 		}
+		data[i+1] = results
+		columns = append(columns, aggName(i, agg))
+	}
 
-		for _, agg := range dfg.aggs {
-			hash = append(hash, key)
-			groups = append(groups, agg(group))
-		}
+	exprData, exprColumns, err := dfg.aggregateGroups(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return New([]interface{}{hash, groups}, []string{dfg.columnName, "count"})
+	return New(append(data, exprData...), append(columns, exprColumns...))
+}
+
+// aggName returns the column name of the i-th aggregation: the lowercased
+// name of the function, or "aggN" with N = i+1 for function literals.
+func aggName(i int, agg func([]interface{}) int) string {
+	name := runtime.FuncForPC(reflect.ValueOf(agg).Pointer()).Name()
+	name = name[strings.LastIndex(name, ".")+1:]
+	if name == "" || strings.HasPrefix(name, "func") {
+		return fmt.Sprintf("agg%d", i+1)
+	}
+	return strings.ToLower(name)
 }
 
-// Count is an aggregation function that returns the number of elements in a group.
+// Count is an aggregation function that returns the number of elements in a group,
+// including null values.
 func Count(group []interface{}) int {
 	return len(group)
 }
 
-// Sum is an aggregation function that returns the sum of numeric elements in a group.
-// Non-numeric values are ignored.
+// Sum is an aggregation function that returns the sum of integer elements in a group.
+// Null and non-integer values are ignored; use an aggregate expression such as
+// Col("value").Sum() with AggExpr to have them reported as ErrTypeMismatch.
 func Sum(group []interface{}) int {
 	sum := 0
 	for _, val := range group {
-		if num, ok := val.(int); ok {
-			sum += num
+		if dt, _ := valueType(val); dt == Int64 {
+			sum += int(toInt64(val))
 		}
 	}
 	return sum
@@ -3,6 +3,7 @@ package dataframe_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 
 	"mkubasz/quanto/internal/dataframe"
@@ -78,10 +79,9 @@ func TestGroupBy(t *testing.T) {
 				return
 			}
 
-			// Each group contributes one row per aggregation
-			// So with 1 aggregation (Count), we should have wantGroups rows
-			if result.Size()/result.NumColumns() != tt.wantGroups {
-				t.Errorf("groups = %d, want %d", result.Size()/result.NumColumns(), tt.wantGroups)
+			// Each group contributes one row
+			if result.NumRows() != tt.wantGroups {
+				t.Errorf("groups = %d, want %d", result.NumRows(), tt.wantGroups)
 			}
 		})
 	}
@@ -138,8 +138,77 @@ func TestGroupByMultipleAgg(t *testing.T) {
 		t.Fatalf("Show failed: %v", err)
 	}
 
-	if result == nil {
-		t.Error("expected non-nil result")
+	if rows, cols := result.Shape(); rows != 2 || cols != 3 {
+		t.Errorf("shape = (%d, %d), want (2, 3)", rows, cols)
+	}
+	if got := result.Columns(); !slices.Equal(got, []string{"category", "count", "sum"}) {
+		t.Errorf("columns = %v, want [category count sum]", got)
+	}
+
+	// Groups are in order of first appearance, and Sum ignores the string keys.
+	want := [][]interface{}{
+		{"A", int64(2), int64(0)},
+		{"B", int64(1), int64(0)},
+	}
+	for i, row := range result.Rows() {
+		if got := row.Values(); !slices.Equal(got, want[i]) {
+			t.Errorf("row %d = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+// TestGroupByNullKeys verifies that null values are grouped together.
+func TestGroupByNullKeys(t *testing.T) {
+	ctx := context.Background()
+
+	df, _ := dataframe.New(
+		[]interface{}{
+			[]interface{}{"A", nil, "A", nil, nil},
+		},
+		[]string{"category"},
+	)
+
+	grouped, err := df.GroupBy(ctx, "category")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	result, err := grouped.Agg(dataframe.Count).Show(ctx)
+	if err != nil {
+		t.Fatalf("Show failed: %v", err)
+	}
+
+	want := [][]interface{}{{"A", int64(2)}, {nil, int64(3)}}
+	for i, row := range result.Rows() {
+		if got := row.Values(); !slices.Equal(got, want[i]) {
+			t.Errorf("row %d = %v, want %v", i, got, want[i])
+		}
+	}
+	if f, _ := result.Schema().Field("category"); !f.Nullable {
+		t.Error("expected grouping column to be nullable")
+	}
+}
+
+// TestGroupBySumTypeMismatch verifies that summing non-numeric values with
+// an aggregate expression fails.
+func TestGroupBySumTypeMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	df, _ := dataframe.New(
+		[]interface{}{
+			[]interface{}{"A", "B", "A"},
+		},
+		[]string{"category"},
+	)
+
+	grouped, err := df.GroupBy(ctx, "category")
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	_, err = grouped.AggExpr(dataframe.Col("category").Sum()).Show(ctx)
+	if !errors.Is(err, dataframe.ErrTypeMismatch) {
+		t.Errorf("expected ErrTypeMismatch, got %v", err)
 	}
 }
 
@@ -253,6 +322,12 @@ func TestAggregationFunctions(t *testing.T) {
 			input:    []interface{}{1, 2, 3, 4, 5},
 			expected: 15,
 		},
+		{
+			name:     "sum with nulls",
+			fn:       dataframe.Sum,
+			input:    []interface{}{1, nil, 2, int64(3)},
+			expected: 6, // Nulls skipped
+		},
 		{
 			name:     "sum with non-integers",
 			fn:       dataframe.Sum,
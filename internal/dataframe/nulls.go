@@ -0,0 +1,155 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Modes of DropNA, deciding how many null values make it drop a row.
+const (
+	// DropAny drops rows with a null value in any of the columns.
+	DropAny = "any"
+	// DropAll drops rows with null values in all of the columns.
+	DropAll = "all"
+)
+
+// IsNull returns a Series of booleans that are true for the rows where the
+// named column is null.
+//
+// Returns ErrColumnNotFound if the column doesn't exist.
+func (df *DataFrame) IsNull(name string) (Series[bool], error) {
+	idx, err := df.getColumnIndex(name)
+	if err != nil {
+		return Series[bool]{}, fmt.Errorf("checking nulls in column %s: %w", name, err)
+	}
+
+	col := df.cols[idx]
+	mask := make([]bool, col.len())
+	for i := range mask {
+		mask[i] = col.isNull(i)
+	}
+	return Series[bool]{Data: mask}, nil
+}
+
+// IsNotNull returns a Series of booleans that are true for the rows where
+// the named column is not null.
+//
+// Returns ErrColumnNotFound if the column doesn't exist.
+func (df *DataFrame) IsNotNull(name string) (Series[bool], error) {
+	mask, err := df.IsNull(name)
+	if err != nil {
+		return Series[bool]{}, err
+	}
+	for i, null := range mask.Data {
+		mask.Data[i] = !null
+	}
+	return mask, nil
+}
+
+// DropNA returns a new DataFrame without the rows that have null values in
+// the subset columns, or in every column if subset is empty. With how set
+// to DropAny a single null value drops the row, with DropAll the row is
+// dropped only if all of the values are null.
+//
+// Returns ErrInvalidData if how is neither DropAny nor DropAll.
+// Returns ErrColumnNotFound if a subset column doesn't exist.
+func (df *DataFrame) DropNA(how string, subset ...string) (*DataFrame, error) {
+	if how != DropAny && how != DropAll {
+		return nil, fmt.Errorf("dropping nulls: %w: how must be %q or %q, got %q", ErrInvalidData, DropAny, DropAll, how)
+	}
+
+	cols := df.cols
+	if len(subset) > 0 {
+		cols = make([]column, len(subset))
+		for i, name := range subset {
+			idx, err := df.getColumnIndex(name)
+			if err != nil {
+				return nil, fmt.Errorf("dropping nulls in column %s: %w", name, err)
+			}
+			cols[i] = df.cols[idx]
+		}
+	}
+
+	var keep []int
+	for i := range df.rows {
+		nulls := 0
+		for _, col := range cols {
+			if col.isNull(i) {
+				nulls++
+			}
+		}
+		if nulls == 0 || (how == DropAll && nulls < len(cols)) {
+			keep = append(keep, i)
+		}
+	}
+
+	return df.take(keep), nil
+}
+
+// FillNA returns a new DataFrame with null values replaced. The value is
+// either a map from column names to the value filling that column, or a
+// single value filling every column of a matching type: an integer fills
+// int64 and float64 columns, a float fills float64 columns, and any other
+// value fills columns of its own type. Columns of the Null type take the
+// type of their fill value.
+//
+// Returns ErrInvalidData if a fill value is nil.
+// Returns ErrColumnNotFound if a column in the map doesn't exist.
+// Returns ErrTypeMismatch if a value in the map doesn't match its column.
+func (df *DataFrame) FillNA(value interface{}) (*DataFrame, error) {
+	cols := slices.Clone(df.cols)
+
+	if values, ok := value.(map[string]interface{}); ok {
+		for _, name := range slices.Sorted(maps.Keys(values)) {
+			idx, err := df.getColumnIndex(name)
+			if err != nil {
+				return nil, fmt.Errorf("filling nulls in column %s: %w", name, err)
+			}
+			col, err := fillColumn(df.cols[idx], values[name])
+			if err != nil {
+				return nil, fmt.Errorf("filling nulls in column %s: %w", name, err)
+			}
+			cols[idx] = col
+		}
+		return df.withColumns(cols), nil
+	}
+
+	for idx, col := range df.cols {
+		filledCol, err := fillColumn(col, value)
+		if errors.Is(err, ErrTypeMismatch) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("filling nulls in column %s: %w", df.fields[idx].Name, err)
+		}
+		cols[idx] = filledCol
+	}
+	return df.withColumns(cols), nil
+}
+
+// fillColumn returns col with its null values replaced by value.
+func fillColumn(col column, value interface{}) (column, error) {
+	dt, goType := valueType(value)
+	if dt == Null {
+		return nil, fmt.Errorf("%w: fill value is nil", ErrInvalidData)
+	}
+	if col.dtype() == Float64 && dt == Int64 {
+		value, dt = float64(toInt64(value)), Float64
+	}
+	if col.dtype() != Null && col.dtype() != dt {
+		return nil, fmt.Errorf("%w: fill value is %s, expected %s", ErrTypeMismatch, typeName(dt, goType), col.dtype())
+	}
+	if col.nullCount() == 0 {
+		return col, nil
+	}
+
+	values := boxValues(col)
+	for i, v := range values {
+		if v == nil {
+			values[i] = value
+		}
+	}
+	return inferColumn(values)
+}
@@ -0,0 +1,279 @@
+package dataframe_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"mkubasz/quanto/internal/dataframe"
+)
+
+// TestNulls verifies that nil values are stored as nulls.
+func TestNulls(t *testing.T) {
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"A", nil, "C", nil},
+			[]interface{}{1, 2, nil, nil},
+			[]interface{}{1.5, nil, 3.5, 4.5},
+		},
+		[]string{"name", "count", "score"},
+	)
+
+	for _, f := range df.Schema().Fields {
+		if !f.Nullable {
+			t.Errorf("expected column %s to be nullable", f.Name)
+		}
+	}
+	if f, _ := df.Schema().Field("count"); f.DType != dataframe.Int64 {
+		t.Errorf("count type = %s, want int64", f.DType)
+	}
+
+	series, _ := df.Select("count")
+	if want := []interface{}{int64(1), int64(2), nil, nil}; !slices.Equal(series.Data, want) {
+		t.Errorf("count = %v, want %v", series.Data, want)
+	}
+
+	allNull := mustNew(t, []interface{}{[]interface{}{nil, nil}}, []string{"col1"})
+	if f, _ := allNull.Schema().Field("col1"); f.DType != dataframe.Null || !f.Nullable {
+		t.Errorf("field = %+v, want nullable null field", f)
+	}
+}
+
+// TestIsNull verifies the null predicates.
+func TestIsNull(t *testing.T) {
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"A", nil, "C", nil},
+			[]interface{}{1, 2, nil, nil},
+			[]interface{}{1.5, nil, 3.5, 4.5},
+		},
+		[]string{"name", "count", "score"},
+	)
+
+	isNull, err := df.IsNull("name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []bool{false, true, false, true}; !slices.Equal(isNull.Data, want) {
+		t.Errorf("IsNull = %v, want %v", isNull.Data, want)
+	}
+
+	isNotNull, err := df.IsNotNull("score")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []bool{true, false, true, true}; !slices.Equal(isNotNull.Data, want) {
+		t.Errorf("IsNotNull = %v, want %v", isNotNull.Data, want)
+	}
+
+	if _, err := df.IsNull("missing"); !errors.Is(err, dataframe.ErrColumnNotFound) {
+		t.Errorf("expected ErrColumnNotFound, got %v", err)
+	}
+}
+
+// TestDropNA verifies dropping rows with null values.
+func TestDropNA(t *testing.T) {
+	tests := []struct {
+		name     string
+		how      string
+		subset   []string
+		wantErr  error
+		wantName []interface{}
+	}{
+		{
+			name:     "any in all columns",
+			how:      dataframe.DropAny,
+			wantName: []interface{}{"A"},
+		},
+		{
+			name:     "all in all columns",
+			how:      dataframe.DropAll,
+			wantName: []interface{}{"A", nil, "C", nil},
+		},
+		{
+			name:     "any in subset",
+			how:      dataframe.DropAny,
+			subset:   []string{"score"},
+			wantName: []interface{}{"A", "C", nil},
+		},
+		{
+			name:     "all in subset",
+			how:      dataframe.DropAll,
+			subset:   []string{"name", "count"},
+			wantName: []interface{}{"A", nil, "C"},
+		},
+		{
+			name:    "invalid how",
+			how:     "some",
+			wantErr: dataframe.ErrInvalidData,
+		},
+		{
+			name:    "missing subset column",
+			how:     dataframe.DropAny,
+			subset:  []string{"missing"},
+			wantErr: dataframe.ErrColumnNotFound,
+		},
+	}
+
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"A", nil, "C", nil},
+			[]interface{}{1, 2, nil, nil},
+			[]interface{}{1.5, nil, 3.5, 4.5},
+		},
+		[]string{"name", "count", "score"},
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := df.DropNA(tt.how, tt.subset...)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.NumRows() != len(tt.wantName) {
+				t.Errorf("rows = %d, want %d", result.NumRows(), len(tt.wantName))
+			}
+			names, _ := result.Select("name")
+			if !slices.Equal(names.Data, tt.wantName) {
+				t.Errorf("names = %v, want %v", names.Data, tt.wantName)
+			}
+			if df.NumRows() != 4 {
+				t.Error("DataFrame was mutated, expected immutability")
+			}
+		})
+	}
+}
+
+// TestFillNA verifies replacing null values.
+func TestFillNA(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr error
+		want    map[string][]interface{}
+	}{
+		{
+			name:  "integer fills numeric columns",
+			value: 0,
+			want: map[string][]interface{}{
+				"name":  {"A", nil, "C", nil},
+				"count": {int64(1), int64(2), int64(0), int64(0)},
+				"score": {1.5, 0.0, 3.5, 4.5},
+			},
+		},
+		{
+			name:  "string fills string columns",
+			value: "?",
+			want: map[string][]interface{}{
+				"name":  {"A", "?", "C", "?"},
+				"count": {int64(1), int64(2), nil, nil},
+			},
+		},
+		{
+			name: "map fills named columns",
+			value: map[string]interface{}{
+				"name":  "unknown",
+				"score": 0.5,
+			},
+			want: map[string][]interface{}{
+				"name":  {"A", "unknown", "C", "unknown"},
+				"count": {int64(1), int64(2), nil, nil},
+				"score": {1.5, 0.5, 3.5, 4.5},
+			},
+		},
+		{
+			name:    "map with mismatched type",
+			value:   map[string]interface{}{"count": "none"},
+			wantErr: dataframe.ErrTypeMismatch,
+		},
+		{
+			name:    "map with missing column",
+			value:   map[string]interface{}{"missing": 1},
+			wantErr: dataframe.ErrColumnNotFound,
+		},
+		{
+			name:    "nil value",
+			value:   nil,
+			wantErr: dataframe.ErrInvalidData,
+		},
+	}
+
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"A", nil, "C", nil},
+			[]interface{}{1, 2, nil, nil},
+			[]interface{}{1.5, nil, 3.5, 4.5},
+		},
+		[]string{"name", "count", "score"},
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := df.FillNA(tt.value)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for name, want := range tt.want {
+				series, _ := result.Select(name)
+				if !slices.Equal(series.Data, want) {
+					t.Errorf("%s = %v, want %v", name, series.Data, want)
+				}
+			}
+			if f, _ := result.Schema().Field("score"); f.DType != dataframe.Float64 {
+				t.Errorf("score type = %s, want float64", f.DType)
+			}
+		})
+	}
+}
+
+// TestFillNANullColumn verifies that a column of nulls takes the type of its fill value.
+func TestFillNANullColumn(t *testing.T) {
+	df, _ := dataframe.New([]interface{}{[]interface{}{nil, nil}}, []string{"col1"})
+
+	result, err := df.FillNA(map[string]interface{}{"col1": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := dataframe.Field{Name: "col1", DType: dataframe.Bool}
+	if f, _ := result.Schema().Field("col1"); f != want {
+		t.Errorf("field = %+v, want %+v", f, want)
+	}
+}
+
+// TestDistinctNulls verifies that null values are deduplicated.
+func TestDistinctNulls(t *testing.T) {
+	df := mustNew(t,
+		[]interface{}{
+			[]interface{}{"A", nil, "C", nil},
+			[]interface{}{1, 2, nil, nil},
+			[]interface{}{1.5, nil, 3.5, 4.5},
+		},
+		[]string{"name", "count", "score"},
+	)
+	series, _ := df.Select("count")
+
+	distinct, err := series.Distinct(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if distinct.Count() != 3 {
+		t.Errorf("distinct count = %d, want 3", distinct.Count())
+	}
+}
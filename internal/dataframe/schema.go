@@ -0,0 +1,102 @@
+package dataframe
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DType is the data type of the values of a DataFrame column.
+type DType int
+
+const (
+	// Null is the type of columns without values to infer a type from.
+	Null DType = iota
+	// Int64 columns hold integers, stored as int64.
+	Int64
+	// Float64 columns hold floating-point numbers, stored as float64.
+	Float64
+	// String columns hold strings.
+	String
+	// Bool columns hold booleans.
+	Bool
+	// Any columns hold values of another Go type, all of the same type.
+	Any
+)
+
+// String returns the name of the data type, e.g. "int64".
+func (t DType) String() string {
+	switch t {
+	case Int64:
+		return "int64"
+	case Float64:
+		return "float64"
+	case String:
+		return "string"
+	case Bool:
+		return "bool"
+	case Any:
+		return "any"
+	default:
+		return "null"
+	}
+}
+
+// Field describes a column of a DataFrame.
+// Nullable reports whether the column contains null values.
+type Field struct {
+	Name     string
+	DType    DType
+	Nullable bool
+}
+
+// newField describes the column col named name.
+func newField(name string, col column) Field {
+	return Field{Name: name, DType: col.dtype(), Nullable: col.dtype() == Null || col.nullCount() > 0}
+}
+
+// Schema describes the columns of a DataFrame, in column order.
+type Schema struct {
+	Fields []Field
+}
+
+// Names returns the names of the fields in order.
+func (s Schema) Names() []string {
+	names := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// Field returns the field with the given name.
+func (s Schema) Field(name string) (Field, bool) {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// String returns the schema as a tree, one field per line.
+func (s Schema) String() string {
+	var b strings.Builder
+	b.WriteString("root\n")
+	for _, f := range s.Fields {
+		fmt.Fprintf(&b, " |-- %s: %s (nullable = %t)\n", f.Name, f.DType, f.Nullable)
+	}
+	return b.String()
+}
+
+// Schema returns the schema of the DataFrame.
+func (df *DataFrame) Schema() Schema {
+	fields := make([]Field, len(df.fields))
+	copy(fields, df.fields)
+	return Schema{Fields: fields}
+}
+
+// PrintSchema prints the schema of the DataFrame to standard output.
+func (df *DataFrame) PrintSchema() {
+	fmt.Fprint(os.Stdout, df.Schema())
+}
@@ -0,0 +1,133 @@
+package dataframe_test
+
+import (
+	"errors"
+	"testing"
+
+	"mkubasz/quanto/internal/dataframe"
+)
+
+// TestSchema verifies the data types inferred for each column.
+func TestSchema(t *testing.T) {
+	df, err := dataframe.New(
+		[]interface{}{
+			[]interface{}{1, 2, 3},
+			[]interface{}{1.5, 2.5, float32(3.5)},
+			[]interface{}{"a", "b", "c"},
+			[]interface{}{true, false, true},
+			[]int{4, 5, 6},
+			[]string{"x", "y", "z"},
+			[]int32{7, 8, 9},
+			[]float32{0.5, 1.5, 2.5},
+		},
+		[]string{"int", "float", "string", "bool", "ints", "strings", "int32s", "float32s"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []dataframe.Field{
+		{Name: "int", DType: dataframe.Int64},
+		{Name: "float", DType: dataframe.Float64},
+		{Name: "string", DType: dataframe.String},
+		{Name: "bool", DType: dataframe.Bool},
+		{Name: "ints", DType: dataframe.Int64},
+		{Name: "strings", DType: dataframe.String},
+		{Name: "int32s", DType: dataframe.Int64},
+		{Name: "float32s", DType: dataframe.Float64},
+	}
+	schema := df.Schema()
+	if len(schema.Fields) != len(want) {
+		t.Fatalf("schema has %d fields, want %d", len(schema.Fields), len(want))
+	}
+	for i, f := range schema.Fields {
+		if f != want[i] {
+			t.Errorf("field %d = %+v, want %+v", i, f, want[i])
+		}
+	}
+
+	if f, ok := schema.Field("float"); !ok || f.DType != dataframe.Float64 {
+		t.Errorf("Field(float) = %+v, %v, want float64 field", f, ok)
+	}
+	if _, ok := schema.Field("missing"); ok {
+		t.Error("expected missing field to be reported")
+	}
+
+	// Modifying the returned schema must not affect the DataFrame.
+	schema.Fields[0].Name = "changed"
+	if df.Schema().Fields[0].Name != "int" {
+		t.Error("DataFrame schema was mutated")
+	}
+}
+
+// TestSchemaValuesAreTyped verifies that values are stored with their column type.
+func TestSchemaValuesAreTyped(t *testing.T) {
+	df, _ := dataframe.New(
+		[]interface{}{
+			[]interface{}{int32(1), 2},
+			[]interface{}{float32(1.5), 2.5},
+		},
+		[]string{"int", "float"},
+	)
+
+	ints, _ := df.Select("int")
+	if _, ok := ints.Data[0].(int64); !ok {
+		t.Errorf("int value is %T, want int64", ints.Data[0])
+	}
+	floats, _ := df.Select("float")
+	if _, ok := floats.Data[0].(float64); !ok {
+		t.Errorf("float value is %T, want float64", floats.Data[0])
+	}
+}
+
+// TestNewRejectsMixedTypes verifies that columns must hold values of one type.
+func TestNewRejectsMixedTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		data []interface{}
+	}{
+		{
+			name: "int and string",
+			data: []interface{}{[]interface{}{1, "2", 3}},
+		},
+		{
+			name: "float and string",
+			data: []interface{}{[]interface{}{1.5, "setosa"}},
+		},
+		{
+			name: "int and float",
+			data: []interface{}{[]interface{}{1, 2.5}},
+		},
+		{
+			name: "different struct types",
+			data: []interface{}{[]interface{}{struct{ A int }{1}, struct{ B int }{2}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := dataframe.New(tt.data, []string{"col1"})
+			if !errors.Is(err, dataframe.ErrTypeMismatch) {
+				t.Errorf("expected ErrTypeMismatch, got %v", err)
+			}
+		})
+	}
+}
+
+// TestSchemaString verifies the tree representation printed by PrintSchema.
+func TestSchemaString(t *testing.T) {
+	df, _ := dataframe.New(
+		[]interface{}{
+			[]interface{}{"A", "B"},
+			[]interface{}{1.5, 2.5},
+		},
+		[]string{"name", "score"},
+	)
+
+	want := "root\n" +
+		" |-- name: string (nullable = false)\n" +
+		" |-- score: float64 (nullable = false)\n"
+	if got := df.Schema().String(); got != want {
+		t.Errorf("schema =\n%s\nwant\n%s", got, want)
+	}
+}
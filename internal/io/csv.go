@@ -50,13 +50,7 @@ func (r *Reader) ReadCSV(fileName string) (_ *dataframe.DataFrame, err error) {
 		return nil, fmt.Errorf("failed to create columns: %w", err)
 	}
 
-	// Convert columns to []interface{} for DataFrame.New
-	data := make([]interface{}, len(columns))
-	for i, col := range columns {
-		data[i] = col.Data
-	}
-
-	df, err := dataframe.New(data, columnNames)
+	df, err := dataframe.New(columns, columnNames)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dataframe: %w", err)
 	}
@@ -64,24 +58,64 @@ func (r *Reader) ReadCSV(fileName string) (_ *dataframe.DataFrame, err error) {
 	return df, nil
 }
 
-func createColumns(columnNames []string, records [][]string) ([]dataframe.Series[interface{}], error) {
+// createColumns splits the records into columns and parses each column into
+// the narrowest type all of its values fit: int64, float64, bool, or string
+// otherwise. Empty cells are nulls and don't affect the type of the column.
+func createColumns(columnNames []string, records [][]string) ([]interface{}, error) {
 	numColumns := len(columnNames)
-	columns := make([]dataframe.Series[interface{}], numColumns)
+	fields := make([][]string, numColumns)
 
-	for _, record := range records {
+	for i, record := range records {
 		if len(record) != numColumns {
-			return nil, errors.New("invalid CSV format: inconsistent number of columns")
+			return nil, fmt.Errorf("invalid CSV format: %w: row %d has %d fields, expected %d",
+				dataframe.ErrInvalidData, i+1, len(record), numColumns)
 		}
 
 		for idx, value := range record {
-			f, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				columns[idx].Data = append(columns[idx].Data, value)
-			} else {
-				columns[idx].Data = append(columns[idx].Data, f)
-			}
+			fields[idx] = append(fields[idx], value)
 		}
 	}
 
+	columns := make([]interface{}, numColumns)
+	for idx, values := range fields {
+		columns[idx] = parseColumn(values)
+	}
+
 	return columns, nil
 }
+
+// parseColumn parses values into the narrowest type all non-empty values
+// fit, with nil for empty values.
+func parseColumn(values []string) []interface{} {
+	if parsed, ok := parseAll(values, func(v string) (int64, error) {
+		return strconv.ParseInt(v, 10, 64)
+	}); ok {
+		return parsed
+	}
+	if parsed, ok := parseAll(values, func(v string) (float64, error) {
+		return strconv.ParseFloat(v, 64)
+	}); ok {
+		return parsed
+	}
+	if parsed, ok := parseAll(values, strconv.ParseBool); ok {
+		return parsed
+	}
+	parsed, _ := parseAll(values, func(v string) (string, error) { return v, nil })
+	return parsed
+}
+
+// parseAll parses every non-empty value with parse, reporting false if any fails.
+func parseAll[T any](values []string, parse func(string) (T, error)) ([]interface{}, bool) {
+	parsed := make([]interface{}, len(values))
+	for i, v := range values {
+		if v == "" {
+			continue
+		}
+		p, err := parse(v)
+		if err != nil {
+			return nil, false
+		}
+		parsed[i] = p
+	}
+	return parsed, true
+}
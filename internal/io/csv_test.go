@@ -1,8 +1,12 @@
 package io_test
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
+	"mkubasz/quanto/internal/dataframe"
 	"mkubasz/quanto/internal/io"
 )
 
@@ -19,3 +23,50 @@ func TestShouldReadCSVFile(t *testing.T) {
 		}
 	}
 }
+
+func TestShouldInferCSVColumnTypes(t *testing.T) {
+	reader := io.NewReader()
+	df, err := reader.ReadCSV("../../testdata/test.csv")
+	if err != nil {
+		t.Fatalf("failed to read csv file: %v", err)
+	}
+	expected := map[string]dataframe.DType{
+		"sepal.length": dataframe.Float64,
+		"sepal.width":  dataframe.Float64,
+		"petal.length": dataframe.Float64,
+		"petal.width":  dataframe.Float64,
+		"variety":      dataframe.String,
+	}
+	for _, f := range df.Schema().Fields {
+		if f.DType != expected[f.Name] {
+			t.Errorf("column %s has type %s, want %s", f.Name, f.DType, expected[f.Name])
+		}
+	}
+}
+
+func TestShouldReadEmptyCSVCellsAsNulls(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "nulls.csv")
+	content := "id,name,score\n1,a,1.5\n2,,\n,c,3\n"
+	if err := os.WriteFile(fileName, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write csv file: %v", err)
+	}
+
+	df, err := io.NewReader().ReadCSV(fileName)
+	if err != nil {
+		t.Fatalf("failed to read csv file: %v", err)
+	}
+
+	expected := []dataframe.Field{
+		{Name: "id", DType: dataframe.Int64, Nullable: true},
+		{Name: "name", DType: dataframe.String, Nullable: true},
+		{Name: "score", DType: dataframe.Float64, Nullable: true},
+	}
+	if fields := df.Schema().Fields; !slices.Equal(fields, expected) {
+		t.Errorf("fields = %+v, want %+v", fields, expected)
+	}
+
+	names, _ := df.Select("name")
+	if want := []interface{}{"a", nil, "c"}; !slices.Equal(names.Data, want) {
+		t.Errorf("names = %v, want %v", names.Data, want)
+	}
+}
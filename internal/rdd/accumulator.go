@@ -0,0 +1,111 @@
+package rdd
+
+import (
+	"context"
+	"sync"
+)
+
+// Accumulator is a variable that tasks add to and whose value is read on the
+// driver. Values are combined with a merge function that must be associative
+// and commutative, since tasks finish in any order.
+//
+// Adds made by a task through its context (see MapContext) are buffered and
+// merged into the accumulator only when the task succeeds, so a task that
+// fails and is retried contributes exactly once. Transformations run again
+// by later actions, unless persisted, add again.
+//
+// An Accumulator is safe for concurrent use.
+type Accumulator[T any] struct {
+	name  string
+	merge func(a, b T) T
+
+	mu    sync.Mutex
+	value T
+}
+
+// NewAccumulator creates an accumulator starting at zero that combines
+// values with merge.
+func NewAccumulator[T any](name string, zero T, merge func(a, b T) T) *Accumulator[T] {
+	return &Accumulator[T]{
+		name:  name,
+		merge: merge,
+		value: zero,
+	}
+}
+
+// Name returns the name of the accumulator.
+func (a *Accumulator[T]) Name() string {
+	return a.name
+}
+
+// Add merges v into the accumulator. Within a task, ctx must be the task
+// context passed to the user function, so that the update is discarded if
+// the task fails. On the driver, v is merged immediately.
+func (a *Accumulator[T]) Add(ctx context.Context, v T) {
+	if t, ok := ctx.Value(taskKey{}).(*task); ok {
+		t.accumulate(a, v)
+		return
+	}
+	a.commit(v)
+}
+
+// Value returns the current value of the accumulator.
+func (a *Accumulator[T]) Value() T {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.value
+}
+
+// commit merges v into the value of the accumulator.
+func (a *Accumulator[T]) commit(v T) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.value = a.merge(a.value, v)
+}
+
+// accumulable is the type-erased view of an Accumulator used by tasks to
+// buffer updates.
+type accumulable interface {
+	mergePending(pending, v any) any
+	commitPending(pending any)
+}
+
+// mergePending merges v into the update buffered by a task.
+func (a *Accumulator[T]) mergePending(pending, v any) any {
+	if pending == nil {
+		return v
+	}
+	return a.merge(pending.(T), v.(T))
+}
+
+// commitPending merges the update buffered by a successful task.
+func (a *Accumulator[T]) commitPending(pending any) {
+	a.commit(pending.(T))
+}
+
+// accumulatorUpdates are the accumulator updates buffered by a task.
+type accumulatorUpdates struct {
+	mu      sync.Mutex
+	pending map[accumulable]any
+}
+
+// accumulate buffers the update of acc by v until the task succeeds.
+func (t *task) accumulate(acc accumulable, v any) {
+	u := &t.updates
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.pending == nil {
+		u.pending = make(map[accumulable]any)
+	}
+	u.pending[acc] = acc.mergePending(u.pending[acc], v)
+}
+
+// commit merges the buffered updates into their accumulators.
+func (u *accumulatorUpdates) commit() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for acc, pending := range u.pending {
+		acc.commitPending(pending)
+	}
+	clear(u.pending)
+}
@@ -0,0 +1,99 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// sum is the merge function of the int64 test accumulators.
+func sum(a, b int64) int64 { return a + b }
+
+// TestAccumulator verifies counting from inside tasks.
+func TestAccumulator(t *testing.T) {
+	ctx := context.Background()
+	malformed := rdd.NewAccumulator("malformed", int64(0), sum)
+
+	r, _ := rdd.NewWithPartitions([]string{"a=1", "b", "c=3", "d", "e", "f=6"}, 3)
+	valid, err := r.FilterContext(ctx, func(ctx context.Context, v string) bool {
+		if !strings.Contains(v, "=") {
+			malformed.Add(ctx, 1)
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := mustCollect(t, valid); len(got) != 3 {
+		t.Errorf("valid records = %v, want 3", got)
+	}
+	if malformed.Value() != 3 {
+		t.Errorf("malformed = %d, want 3", malformed.Value())
+	}
+	if malformed.Name() != "malformed" {
+		t.Errorf("name = %q, want malformed", malformed.Name())
+	}
+
+	// Adds on the driver apply immediately.
+	malformed.Add(ctx, 10)
+	if malformed.Value() != 13 {
+		t.Errorf("malformed = %d, want 13", malformed.Value())
+	}
+}
+
+// TestAccumulatorExactlyOnce verifies that the updates of failed task
+// attempts are discarded.
+func TestAccumulatorExactlyOnce(t *testing.T) {
+	ctx := context.Background()
+	seen := rdd.NewAccumulator("seen", int64(0), sum)
+	var failed atomic.Bool
+
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4, 5, 6, 7, 8}, 4)
+	mapped, _ := rdd.MapContext(ctx, r, func(ctx context.Context, v int) int {
+		seen.Add(ctx, 1)
+		return v
+	})
+	failing, _ := mapped.MapErr(ctx, func(v int) (int, error) {
+		if v == 6 && !failed.Swap(true) {
+			return 0, errors.New("transient failure")
+		}
+		return v, nil
+	})
+
+	retrying := rdd.WithRetryPolicy(ctx, rdd.RetryPolicy{MaxAttempts: 2})
+	if _, err := failing.Collect(retrying); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen.Value() != 8 {
+		t.Errorf("seen = %d, want 8", seen.Value())
+	}
+}
+
+// TestAccumulatorFailedJob verifies that failed tasks do not update
+// accumulators.
+func TestAccumulatorFailedJob(t *testing.T) {
+	ctx := context.Background()
+	seen := rdd.NewAccumulator("seen", int64(0), sum)
+
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4}, 1)
+	mapped, _ := rdd.MapContext(ctx, r, func(ctx context.Context, v int) int {
+		seen.Add(ctx, 1)
+		if v == 3 {
+			panic("boom")
+		}
+		return v
+	})
+
+	if _, err := mapped.Collect(ctx); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if seen.Value() != 0 {
+		t.Errorf("seen = %d, want 0", seen.Value())
+	}
+}
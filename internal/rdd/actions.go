@@ -0,0 +1,292 @@
+package rdd
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// takeScaleFactor is how much Take grows the number of partitions it scans
+// each time the partitions scanned so far did not hold enough elements.
+const takeScaleFactor = 4
+
+// Reduce combines the elements of the RDD using fn, which must be
+// commutative and associative. Each partition is reduced by its own task and
+// the partial results are combined once all tasks complete.
+//
+// Returns ErrEmptyRDD if the RDD has no elements.
+// Returns context.Canceled if the context is canceled during processing.
+func (r *RDD[T]) Reduce(ctx context.Context, fn func(T, T) T) (T, error) {
+	type partial struct {
+		value T
+		ok    bool
+	}
+
+	var zero T
+	partials, err := runJob(ctx, r, func(_ *task, it iter.Seq[T]) partial {
+		var acc partial
+		for v := range it {
+			if !acc.ok {
+				acc = partial{value: v, ok: true}
+				continue
+			}
+			acc.value = fn(acc.value, v)
+		}
+		return acc
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	var result partial
+	for _, p := range partials {
+		if !p.ok {
+			continue
+		}
+		if !result.ok {
+			result = p
+			continue
+		}
+		result.value = fn(result.value, p.value)
+	}
+	if !result.ok {
+		return zero, fmt.Errorf("reducing rdd: %w", ErrEmptyRDD)
+	}
+
+	return result.value, nil
+}
+
+// Fold combines the elements of the RDD using op, starting every partition
+// and the final combination from zero. The zero value should be the identity
+// of op, e.g. 0 for addition, as it is applied once per partition.
+//
+// Returns context.Canceled if the context is canceled during processing.
+func (r *RDD[T]) Fold(ctx context.Context, zero T, op func(T, T) T) (T, error) {
+	partials, err := runJob(ctx, r, func(_ *task, it iter.Seq[T]) T {
+		acc := zero
+		for v := range it {
+			acc = op(acc, v)
+		}
+		return acc
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	result := zero
+	for _, p := range partials {
+		result = op(result, p)
+	}
+	return result, nil
+}
+
+// Aggregate combines the elements of r into a value of a different type.
+// Each partition folds its elements into zero with seqOp, then the partial
+// results are merged with combOp. The zero value is shared by all
+// partitions, so seqOp and combOp must not mutate it in place.
+//
+// Returns context.Canceled if the context is canceled during processing.
+func Aggregate[T, U any](ctx context.Context, r *RDD[T], zero U, seqOp func(U, T) U, combOp func(U, U) U) (U, error) {
+	partials, err := runJob(ctx, r, func(_ *task, it iter.Seq[T]) U {
+		acc := zero
+		for v := range it {
+			acc = seqOp(acc, v)
+		}
+		return acc
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	result := zero
+	for _, p := range partials {
+		result = combOp(result, p)
+	}
+	return result, nil
+}
+
+// Take returns the first n elements of the RDD.
+// Partitions are scanned in order, starting with a single one and scanning
+// more at a time until n elements are found, so only the partitions needed
+// are computed.
+//
+// Returns context.Canceled if the context is canceled during processing.
+func (r *RDD[T]) Take(ctx context.Context, n int) ([]T, error) {
+	result := []T{}
+	scanned, batch := 0, 1
+
+	for scanned < r.numParts && len(result) < n {
+		end := min(scanned+batch, r.numParts)
+		partitions := make([]int, 0, end-scanned)
+		for p := scanned; p < end; p++ {
+			partitions = append(partitions, p)
+		}
+
+		left := n - len(result)
+		parts, err := runJobOn(ctx, r, partitions, func(_ *task, it iter.Seq[T]) []T {
+			var out []T
+			for v := range it {
+				if len(out) == left {
+					break
+				}
+				out = append(out, v)
+			}
+			return out
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, part := range parts {
+			result = append(result, part[:min(len(part), n-len(result))]...)
+		}
+		scanned = end
+		batch *= takeScaleFactor
+	}
+
+	return result, nil
+}
+
+// First returns the first element of the RDD.
+//
+// Returns ErrEmptyRDD if the RDD has no elements.
+// Returns context.Canceled if the context is canceled during processing.
+func (r *RDD[T]) First(ctx context.Context) (T, error) {
+	var zero T
+	first, err := r.Take(ctx, 1)
+	if err != nil {
+		return zero, err
+	}
+	if len(first) == 0 {
+		return zero, fmt.Errorf("getting first element: %w", ErrEmptyRDD)
+	}
+	return first[0], nil
+}
+
+// TakeOrdered returns the n smallest elements of the RDD according to less,
+// in ascending order. Each task keeps only its n smallest elements in a
+// bounded heap, so at most n elements per partition reach the driver.
+//
+// Returns context.Canceled if the context is canceled during processing.
+func (r *RDD[T]) TakeOrdered(ctx context.Context, n int, less func(a, b T) bool) ([]T, error) {
+	if n <= 0 {
+		return []T{}, nil
+	}
+
+	partials, err := runJob(ctx, r, func(_ *task, it iter.Seq[T]) []T {
+		h := &boundedHeap[T]{limit: n, less: less}
+		for v := range it {
+			h.offer(v)
+		}
+		return h.items
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h := &boundedHeap[T]{limit: n, less: less}
+	for _, partial := range partials {
+		for _, v := range partial {
+			h.offer(v)
+		}
+	}
+
+	result := h.items
+	slices.SortStableFunc(result, func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return result, nil
+}
+
+// Top returns the n largest elements of the RDD according to less, in
+// descending order. See TakeOrdered for how the elements are selected.
+//
+// Returns context.Canceled if the context is canceled during processing.
+func (r *RDD[T]) Top(ctx context.Context, n int, less func(a, b T) bool) ([]T, error) {
+	return r.TakeOrdered(ctx, n, func(a, b T) bool { return less(b, a) })
+}
+
+// Foreach applies fn to every element of the RDD for its side effects.
+// Elements of different partitions are processed concurrently, so fn must be
+// safe for concurrent use.
+// This is an action: it executes the lineage of the RDD.
+//
+// Returns context.Canceled if the context is canceled during processing.
+func (r *RDD[T]) Foreach(ctx context.Context, fn func(T)) error {
+	_, err := runJob(ctx, r, func(_ *task, it iter.Seq[T]) struct{} {
+		for v := range it {
+			fn(v)
+		}
+		return struct{}{}
+	})
+	return err
+}
+
+// ForeachPartition calls fn once for every partition of the RDD with an
+// iterator over its elements, so that setup such as opening a connection
+// happens once per partition rather than once per element. The context
+// passed to fn is the task context. An error returned by fn fails the task
+// and is returned as a *TaskError.
+// This is an action: it executes the lineage of the RDD.
+//
+// Returns context.Canceled if the context is canceled during processing.
+func (r *RDD[T]) ForeachPartition(ctx context.Context, fn func(ctx context.Context, it iter.Seq[T]) error) error {
+	_, err := runJob(ctx, r, func(t *task, it iter.Seq[T]) struct{} {
+		if err := fn(t.ctx, it); err != nil {
+			t.fail(err)
+		}
+		return struct{}{}
+	})
+	return err
+}
+
+// boundedHeap keeps the limit smallest elements offered to it. The largest
+// kept element sits at the root so it can be replaced in logarithmic time.
+type boundedHeap[T any] struct {
+	items []T
+	limit int
+	less  func(a, b T) bool
+}
+
+// offer adds v to the heap if it is among the limit smallest elements seen.
+func (h *boundedHeap[T]) offer(v T) {
+	if len(h.items) < h.limit {
+		heap.Push(h, v)
+		return
+	}
+	if h.less(v, h.items[0]) {
+		h.items[0] = v
+		heap.Fix(h, 0)
+	}
+}
+
+// Len implements heap.Interface.
+func (h *boundedHeap[T]) Len() int { return len(h.items) }
+
+// Less implements heap.Interface, ordering the largest element first.
+func (h *boundedHeap[T]) Less(i, j int) bool { return h.less(h.items[j], h.items[i]) }
+
+// Swap implements heap.Interface.
+func (h *boundedHeap[T]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+// Push implements heap.Interface.
+func (h *boundedHeap[T]) Push(x any) {
+	v, _ := x.(T)
+	h.items = append(h.items, v)
+}
+
+// Pop implements heap.Interface.
+func (h *boundedHeap[T]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
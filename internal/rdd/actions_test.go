@@ -0,0 +1,276 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// TestReduce verifies reducing elements across partitions.
+func TestReduce(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		numParts int
+		expected int
+		wantErr  error
+	}{
+		{
+			name:     "sum across partitions",
+			input:    []int{1, 2, 3, 4, 5},
+			numParts: 3,
+			expected: 15,
+		},
+		{
+			name:     "more partitions than elements",
+			input:    []int{7},
+			numParts: 4,
+			expected: 7,
+		},
+		{
+			name:     "empty rdd",
+			input:    []int{},
+			numParts: 2,
+			wantErr:  rdd.ErrEmptyRDD,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := rdd.NewWithPartitions(tt.input, tt.numParts)
+			got, err := r.Reduce(context.Background(), func(a, b int) int { return a + b })
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("result = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFold verifies folding with a zero value.
+func TestFold(t *testing.T) {
+	ctx := context.Background()
+
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4}, 2)
+	got, err := r.Fold(ctx, 1, func(a, b int) int { return a * b })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 24 {
+		t.Errorf("result = %d, want 24", got)
+	}
+
+	empty := rdd.New([]int{})
+	got, err = empty.Fold(ctx, 0, func(a, b int) int { return a + b })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 0 {
+		t.Errorf("result = %d, want 0", got)
+	}
+}
+
+// TestAggregate verifies aggregation into a different type.
+func TestAggregate(t *testing.T) {
+	type stats struct {
+		count int
+		sum   int
+	}
+
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4, 5, 6}, 3)
+	got, err := rdd.Aggregate(context.Background(), r, stats{},
+		func(acc stats, n int) stats {
+			return stats{count: acc.count + 1, sum: acc.sum + n}
+		},
+		func(a, b stats) stats {
+			return stats{count: a.count + b.count, sum: a.sum + b.sum}
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.count != 6 || got.sum != 21 {
+		t.Errorf("result = %+v, want count 6 and sum 21", got)
+	}
+}
+
+// TestReduceCancellation verifies that reductions respect context cancellation.
+func TestReduceCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel immediately.
+
+	r := rdd.New([]int{1, 2, 3})
+	sum := func(a, b int) int { return a + b }
+
+	if _, err := r.Reduce(ctx, sum); !errors.Is(err, context.Canceled) {
+		t.Errorf("Reduce: expected context.Canceled error, got: %v", err)
+	}
+	if _, err := r.Fold(ctx, 0, sum); !errors.Is(err, context.Canceled) {
+		t.Errorf("Fold: expected context.Canceled error, got: %v", err)
+	}
+	if _, err := rdd.Aggregate(ctx, r, 0, sum, sum); !errors.Is(err, context.Canceled) {
+		t.Errorf("Aggregate: expected context.Canceled error, got: %v", err)
+	}
+}
+
+// TestTake verifies taking the first elements across partitions.
+func TestTake(t *testing.T) {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+	r, _ := rdd.NewWithPartitions(data, 10)
+
+	tests := []struct {
+		name     string
+		n        int
+		expected []int
+	}{
+		{name: "within first partition", n: 3, expected: []int{0, 1, 2}},
+		{name: "across partitions", n: 25, expected: data[:25]},
+		{name: "more than available", n: 500, expected: data},
+		{name: "zero", n: 0, expected: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Take(context.Background(), tt.n)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("result = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTakeScansOnlyNeededPartitions verifies that Take stops early.
+func TestTakeScansOnlyNeededPartitions(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int64
+
+	data := make([]int, 100)
+	r, _ := rdd.NewWithPartitions(data, 10)
+	mapped, _ := r.Map(ctx, func(n int) int {
+		calls.Add(1)
+		return n
+	})
+
+	if _, err := mapped.Take(ctx, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() > 10 {
+		t.Errorf("map ran %d times, want at most one partition", calls.Load())
+	}
+}
+
+// TestFirst verifies returning the first element.
+func TestFirst(t *testing.T) {
+	ctx := context.Background()
+
+	r, _ := rdd.NewWithPartitions([]string{"x", "y"}, 2)
+	got, err := r.First(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "x" {
+		t.Errorf("first = %q, want %q", got, "x")
+	}
+
+	if _, err = rdd.New([]string{}).First(ctx); !errors.Is(err, rdd.ErrEmptyRDD) {
+		t.Errorf("expected error %v, got %v", rdd.ErrEmptyRDD, err)
+	}
+}
+
+// TestTopAndTakeOrdered verifies bounded top-N selection.
+func TestTopAndTakeOrdered(t *testing.T) {
+	ctx := context.Background()
+	less := func(a, b int) bool { return a < b }
+
+	r, _ := rdd.NewWithPartitions([]int{5, 1, 9, 3, 7, 2, 8, 6, 4}, 4)
+
+	top, err := r.Top(ctx, 3, less)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(top, []int{9, 8, 7}) {
+		t.Errorf("top = %v, want [9 8 7]", top)
+	}
+
+	smallest, err := r.TakeOrdered(ctx, 4, less)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(smallest, []int{1, 2, 3, 4}) {
+		t.Errorf("smallest = %v, want [1 2 3 4]", smallest)
+	}
+
+	all, err := r.TakeOrdered(ctx, 100, less)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(all, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("all = %v, want sorted input", all)
+	}
+}
+
+// TestForeach verifies applying a function to every element.
+func TestForeach(t *testing.T) {
+	ctx := context.Background()
+	var sum atomic.Int64
+
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4, 5}, 3)
+	if err := r.Foreach(ctx, func(v int) { sum.Add(int64(v)) }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sum.Load() != 15 {
+		t.Errorf("sum = %d, want 15", sum.Load())
+	}
+}
+
+// TestForeachPartition verifies per-partition processing and errors.
+func TestForeachPartition(t *testing.T) {
+	ctx := context.Background()
+	var partitions, elements atomic.Int64
+
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4, 5}, 3)
+	err := r.ForeachPartition(ctx, func(_ context.Context, it iter.Seq[int]) error {
+		partitions.Add(1)
+		for range it {
+			elements.Add(1)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if partitions.Load() != 3 || elements.Load() != 5 {
+		t.Errorf("partitions = %d, elements = %d, want 3 and 5", partitions.Load(), elements.Load())
+	}
+
+	errConnect := errors.New("connection refused")
+	err = r.ForeachPartition(ctx, func(context.Context, iter.Seq[int]) error {
+		return errConnect
+	})
+	var taskErr *rdd.TaskError
+	if !errors.Is(err, errConnect) || !errors.As(err, &taskErr) {
+		t.Errorf("expected task error wrapping %v, got %v", errConnect, err)
+	}
+}
@@ -0,0 +1,332 @@
+package rdd
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// blockID identifies a partition of a persisted RDD.
+type blockID struct {
+	rdd       uint64
+	partition int
+}
+
+// memoryBlock is a partition held in memory.
+type memoryBlock struct {
+	id    blockID
+	level StorageLevel
+	data  any // []T
+	size  int64
+	// spill writes the partition to the given file when it is evicted from
+	// memory; it is nil for blocks that are dropped instead.
+	spill func(path string) error
+}
+
+// BlockManager stores the partitions of persisted RDDs. Partitions kept in
+// memory are accounted against a memory budget and evicted in least recently
+// used order when it is exceeded; partitions on disk are serialized with gob
+// to a spill directory.
+//
+// A BlockManager is safe for concurrent use by multiple tasks.
+type BlockManager struct {
+	dir    string
+	budget int64
+
+	mu       sync.Mutex
+	used     int64
+	lru      *list.List // of *memoryBlock, most recently used first
+	memory   map[blockID]*list.Element
+	disk     map[blockID]string
+	spillDir string // created on first spill
+}
+
+// NewBlockManager creates a block manager that spills partitions to a
+// directory created inside dir, or inside the default temporary directory
+// when dir is empty. Partitions in memory may use up to memoryBudget bytes,
+// as estimated from their contents; a budget of zero or less disables the
+// limit.
+func NewBlockManager(dir string, memoryBudget int64) *BlockManager {
+	return &BlockManager{
+		dir:    dir,
+		budget: memoryBudget,
+		lru:    list.New(),
+		memory: make(map[blockID]*list.Element),
+		disk:   make(map[blockID]string),
+	}
+}
+
+// defaultBlockManager stores persisted partitions of jobs whose context does
+// not carry a block manager.
+var defaultBlockManager = NewBlockManager("", 0)
+
+type blockManagerKey struct{}
+
+// WithBlockManager returns a copy of ctx whose jobs store persisted
+// partitions in bm. Jobs run with a context without a block manager use a
+// process-wide one with no memory limit.
+func WithBlockManager(ctx context.Context, bm *BlockManager) context.Context {
+	return context.WithValue(ctx, blockManagerKey{}, bm)
+}
+
+// blockManagerFrom returns the block manager carried by ctx, or the default.
+func blockManagerFrom(ctx context.Context) *BlockManager {
+	if bm, ok := ctx.Value(blockManagerKey{}).(*BlockManager); ok {
+		return bm
+	}
+	return defaultBlockManager
+}
+
+// MemoryUsed returns the estimated number of bytes of partitions in memory.
+func (bm *BlockManager) MemoryUsed() int64 {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	return bm.used
+}
+
+// Close drops every stored partition and removes the spill directory.
+func (bm *BlockManager) Close() error {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	bm.lru.Init()
+	clear(bm.memory)
+	clear(bm.disk)
+	bm.used = 0
+
+	if bm.spillDir == "" {
+		return nil
+	}
+	dir := bm.spillDir
+	bm.spillDir = ""
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("removing spill directory: %w", err)
+	}
+	return nil
+}
+
+// getBlock returns the partition stored under id, reading it from disk if
+// it is not in memory. Partitions that can no longer be read are reported as
+// missing so that they are recomputed.
+func getBlock[T any](bm *BlockManager, id blockID) ([]T, bool) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if elem, ok := bm.memory[id]; ok {
+		bm.lru.MoveToFront(elem)
+		return elem.Value.(*memoryBlock).data.([]T), true
+	}
+
+	path, ok := bm.disk[id]
+	if !ok {
+		return nil, false
+	}
+	data, err := readBlock[T](path)
+	if err != nil {
+		delete(bm.disk, id)
+		return nil, false
+	}
+	return data, true
+}
+
+// putBlock stores data under id at the given storage level. Blocks that
+// do not fit in memory are spilled to disk when the level allows it and
+// dropped otherwise.
+func putBlock[T any](bm *BlockManager, id blockID, level StorageLevel, data []T) error {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	bm.remove(id)
+
+	if level == DiskOnly {
+		return bm.writeToDisk(id, func(path string) error {
+			return writeBlock(path, data)
+		})
+	}
+
+	block := &memoryBlock{
+		id:    id,
+		level: level,
+		data:  data,
+		size:  estimateSize(data),
+	}
+	if level == MemoryAndDisk {
+		block.spill = func(path string) error {
+			return writeBlock(path, data)
+		}
+	}
+
+	if bm.budget > 0 && block.size > bm.budget {
+		if block.spill == nil {
+			return nil
+		}
+		return bm.writeToDisk(id, block.spill)
+	}
+
+	bm.memory[id] = bm.lru.PushFront(block)
+	bm.used += block.size
+	bm.evict()
+	return nil
+}
+
+// evict moves least recently used blocks out of memory until the memory in
+// use fits the budget. Evicted blocks are spilled when their level allows
+// it; blocks that fail to spill are dropped and recomputed when needed.
+func (bm *BlockManager) evict() {
+	for bm.budget > 0 && bm.used > bm.budget {
+		block := bm.lru.Remove(bm.lru.Back()).(*memoryBlock)
+		delete(bm.memory, block.id)
+		bm.used -= block.size
+		if block.spill != nil {
+			_ = bm.writeToDisk(block.id, block.spill)
+		}
+	}
+}
+
+// writeToDisk writes the block under id to the spill directory using write.
+func (bm *BlockManager) writeToDisk(id blockID, write func(path string) error) error {
+	if bm.spillDir == "" {
+		dir, err := os.MkdirTemp(bm.dir, "quanto-blocks-")
+		if err != nil {
+			return fmt.Errorf("creating spill directory: %w", err)
+		}
+		bm.spillDir = dir
+	}
+
+	path := filepath.Join(bm.spillDir, fmt.Sprintf("rdd_%d_%d", id.rdd, id.partition))
+	if err := write(path); err != nil {
+		return fmt.Errorf("spilling partition %d: %w", id.partition, err)
+	}
+	bm.disk[id] = path
+	return nil
+}
+
+// remove drops the block stored under id from memory and disk.
+func (bm *BlockManager) remove(id blockID) {
+	if elem, ok := bm.memory[id]; ok {
+		bm.lru.Remove(elem)
+		delete(bm.memory, id)
+		bm.used -= elem.Value.(*memoryBlock).size
+	}
+	if path, ok := bm.disk[id]; ok {
+		_ = os.Remove(path)
+		delete(bm.disk, id)
+	}
+}
+
+// removeRDD drops every block of the persisted RDD with the given id.
+func (bm *BlockManager) removeRDD(rdd uint64) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	for id := range bm.memory {
+		if id.rdd == rdd {
+			bm.remove(id)
+		}
+	}
+	for id := range bm.disk {
+		if id.rdd == rdd {
+			bm.remove(id)
+		}
+	}
+}
+
+// writeBlock serializes data to a new file at path.
+func writeBlock[T any](path string, data []T) error {
+	return encodeFile(path, GobCodec{}, slices.Values(data))
+}
+
+// readBlock deserializes a partition written by writeBlock.
+func readBlock[T any](path string) ([]T, error) {
+	data := []T{}
+	err := decodeFile(path, GobCodec{}, func(v T) bool {
+		data = append(data, v)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// sizeSamples is the number of elements estimateSize inspects per partition.
+const sizeSamples = 64
+
+// estimateSize estimates the number of bytes data occupies in memory. Only
+// a sample of evenly spaced elements is inspected, and the result is scaled
+// to the length of data.
+func estimateSize[T any](data []T) int64 {
+	if len(data) == 0 {
+		return 0
+	}
+
+	elemSize := int64(reflect.TypeFor[T]().Size())
+	step := max(len(data)/sizeSamples, 1)
+	var sampled, indirect int64
+	for i := 0; i < len(data); i += step {
+		indirect += indirectSize(reflect.ValueOf(&data[i]).Elem(), make(map[uintptr]bool))
+		sampled++
+	}
+	return int64(len(data)) * (elemSize + indirect/sampled)
+}
+
+// indirectSize estimates the bytes v references outside of its own inline
+// storage, such as string contents, slice backing arrays and pointed-to
+// values. Pointers already in seen are not counted again.
+func indirectSize(v reflect.Value, seen map[uintptr]bool) int64 {
+	switch v.Kind() {
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Slice:
+		if v.IsNil() {
+			return 0
+		}
+		n := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		for i := range v.Len() {
+			n += indirectSize(v.Index(i), seen)
+		}
+		return n
+	case reflect.Array:
+		var n int64
+		for i := range v.Len() {
+			n += indirectSize(v.Index(i), seen)
+		}
+		return n
+	case reflect.Struct:
+		var n int64
+		for i := range v.NumField() {
+			n += indirectSize(v.Field(i), seen)
+		}
+		return n
+	case reflect.Pointer:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + indirectSize(elem, seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + indirectSize(elem, seen)
+	case reflect.Map:
+		if v.IsNil() {
+			return 0
+		}
+		n := int64(v.Len()) * int64(v.Type().Key().Size()+v.Type().Elem().Size())
+		iter := v.MapRange()
+		for iter.Next() {
+			n += indirectSize(iter.Key(), seen) + indirectSize(iter.Value(), seen)
+		}
+		return n
+	default:
+		return 0
+	}
+}
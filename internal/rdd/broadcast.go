@@ -0,0 +1,99 @@
+package rdd
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// nextBroadcastID allocates the identifiers of broadcast variables.
+var nextBroadcastID atomic.Uint64
+
+// Broadcast is a read-only value shared by all tasks of a session. Tasks
+// read it through Value instead of capturing it in their closures.
+//
+// A serialized broadcast (see NewSerializedBroadcast) keeps the encoded
+// value to ship to workers, and each worker process decodes it once, on the
+// first call to Value.
+//
+// A Broadcast is safe for concurrent use.
+type Broadcast[T any] struct {
+	id    uint64
+	codec Codec
+
+	mu        sync.RWMutex
+	payload   []byte
+	value     T
+	decoded   bool
+	destroyed bool
+}
+
+// NewBroadcast returns a broadcast of value shared in memory by the tasks of
+// this process.
+func NewBroadcast[T any](value T) *Broadcast[T] {
+	return &Broadcast[T]{
+		id:      nextBroadcastID.Add(1),
+		value:   value,
+		decoded: true,
+	}
+}
+
+// NewSerializedBroadcast returns a broadcast of value encoded once with
+// codec, as used to ship the value to cluster workers.
+func NewSerializedBroadcast[T any](value T, codec Codec) (*Broadcast[T], error) {
+	var buf bytes.Buffer
+	if err := codec.NewEncoder(&buf).Encode(&value); err != nil {
+		return nil, fmt.Errorf("encoding broadcast: %w", err)
+	}
+	return &Broadcast[T]{
+		id:      nextBroadcastID.Add(1),
+		codec:   codec,
+		payload: buf.Bytes(),
+	}, nil
+}
+
+// ID returns the identifier of the broadcast.
+func (b *Broadcast[T]) ID() uint64 {
+	return b.id
+}
+
+// Value returns the broadcast value. Callers must not modify it.
+//
+// Value panics if the broadcast was destroyed or its payload cannot be
+// decoded; inside a task the panic fails the task with a *TaskError.
+func (b *Broadcast[T]) Value() T {
+	b.mu.RLock()
+	if b.decoded && !b.destroyed {
+		defer b.mu.RUnlock()
+		return b.value
+	}
+	b.mu.RUnlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.destroyed {
+		panic(fmt.Sprintf("rdd: broadcast %d used after Destroy", b.id))
+	}
+	if !b.decoded {
+		var value T
+		if err := b.codec.NewDecoder(bytes.NewReader(b.payload)).Decode(&value); err != nil {
+			panic(fmt.Sprintf("rdd: decoding broadcast %d: %v", b.id, err))
+		}
+		b.value = value
+		b.decoded = true
+	}
+	return b.value
+}
+
+// Destroy releases the broadcast value and its serialized payload. The
+// broadcast cannot be used afterwards.
+func (b *Broadcast[T]) Destroy() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var zero T
+	b.value = zero
+	b.payload = nil
+	b.destroyed = true
+}
@@ -0,0 +1,68 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// TestBroadcast verifies reading broadcast values from tasks.
+func TestBroadcast(t *testing.T) {
+	lookup := map[string]int{"a": 1, "b": 2}
+	serialized, err := rdd.NewSerializedBroadcast(lookup, rdd.GobCodec{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	broadcasts := map[string]*rdd.Broadcast[map[string]int]{
+		"in memory":  rdd.NewBroadcast(lookup),
+		"serialized": serialized,
+	}
+
+	for name, b := range broadcasts {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r, _ := rdd.NewWithPartitions([]string{"a", "b", "a", "c"}, 2)
+			mapped, _ := rdd.Map(ctx, r, func(k string) int {
+				return b.Value()[k]
+			})
+
+			if got := mustCollect(t, mapped); !slices.Equal(got, []int{1, 2, 1, 0}) {
+				t.Errorf("result = %v, want [1 2 1 0]", got)
+			}
+		})
+	}
+}
+
+// TestSerializedBroadcastInterface verifies decoding a serialized broadcast
+// of an interface type.
+func TestSerializedBroadcastInterface(t *testing.T) {
+	b, err := rdd.NewSerializedBroadcast[any]("lookup", rdd.GobCodec{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := b.Value(); got != "lookup" {
+		t.Errorf("value = %v, want lookup", got)
+	}
+}
+
+// TestBroadcastDestroy verifies that destroyed broadcasts fail tasks using
+// them.
+func TestBroadcastDestroy(t *testing.T) {
+	ctx := context.Background()
+	b := rdd.NewBroadcast([]int{1, 2, 3})
+	b.Destroy()
+
+	mapped, _ := rdd.Map(ctx, rdd.New([]int{0}), func(i int) int {
+		return b.Value()[i]
+	})
+
+	_, err := mapped.Collect(ctx)
+	var taskErr *rdd.TaskError
+	if !errors.As(err, &taskErr) {
+		t.Errorf("expected *rdd.TaskError, got %v", err)
+	}
+}
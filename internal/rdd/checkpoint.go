@@ -0,0 +1,93 @@
+package rdd
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+)
+
+type (
+	checkpointDirKey struct{}
+	codecKey         struct{}
+)
+
+// WithCheckpointDir returns a copy of ctx in which Checkpoint writes to dir
+// when it is called without a directory.
+func WithCheckpointDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, checkpointDirKey{}, dir)
+}
+
+// WithCodec returns a copy of ctx in which Checkpoint serializes elements
+// with codec. Without it, checkpoints use GobCodec.
+func WithCodec(ctx context.Context, codec Codec) context.Context {
+	return context.WithValue(ctx, codecKey{}, codec)
+}
+
+// codecFrom returns the codec carried by ctx, or GobCodec.
+func codecFrom(ctx context.Context) Codec {
+	if codec, ok := ctx.Value(codecKey{}).(Codec); ok {
+		return codec
+	}
+	return GobCodec{}
+}
+
+// Checkpoint computes the RDD and writes every partition to a new directory
+// inside dir, or inside the checkpoint directory of ctx when dir is empty.
+// Elements are serialized with the codec of ctx (see WithCodec).
+//
+// The returned RDD reads its partitions back from those files and has no
+// parents, so its lineage no longer includes the transformations that
+// produced the data. Checkpoint files are kept after the job; removing them
+// is left to the caller.
+//
+// Returns ErrNoCheckpointDir if no directory is given.
+func (r *RDD[T]) Checkpoint(ctx context.Context, dir string) (*RDD[T], error) {
+	if dir == "" {
+		dir, _ = ctx.Value(checkpointDirKey{}).(string)
+	}
+	if dir == "" {
+		return nil, fmt.Errorf("checkpointing rdd: %w", ErrNoCheckpointDir)
+	}
+	codec := codecFrom(ctx)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("checkpointing rdd: %w", err)
+	}
+	dir, err := os.MkdirTemp(dir, "checkpoint-")
+	if err != nil {
+		return nil, fmt.Errorf("checkpointing rdd: %w", err)
+	}
+
+	partPath := func(split int) string {
+		return filepath.Join(dir, fmt.Sprintf("part-%05d", split))
+	}
+
+	_, err = runJob(ctx, r, func(t *task, it iter.Seq[T]) struct{} {
+		if err := encodeFile(partPath(t.partition), codec, it); err != nil {
+			t.fail(fmt.Errorf("writing checkpoint partition %d: %w", t.partition, err))
+		}
+		return struct{}{}
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return &RDD[T]{
+		op:       "Checkpoint",
+		numParts: r.numParts,
+		compute: func(t *task, split int) iter.Seq[T] {
+			return func(yield func(T) bool) {
+				err := decodeFile(partPath(split), codec, func(v T) bool {
+					return !t.canceled() && yield(v)
+				})
+				if err != nil {
+					t.fail(fmt.Errorf("reading checkpoint partition %d: %w", split, err))
+				}
+			}
+		},
+		unordered: r.unordered,
+	}, nil
+}
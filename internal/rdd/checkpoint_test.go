@@ -0,0 +1,149 @@
+package rdd_test
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"io"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// TestCheckpoint verifies that checkpointing keeps the data and truncates
+// the lineage.
+func TestCheckpoint(t *testing.T) {
+	ctx := context.Background()
+
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+	source, _ := rdd.NewWithPartitions(data, 4)
+
+	var calls atomic.Int64
+	mapped, _ := source.Map(ctx, func(v int) int {
+		calls.Add(1)
+		return v * 2
+	})
+	pairs, _ := rdd.Map(ctx, mapped, func(v int) rdd.Pair[int, int] {
+		return rdd.Pair[int, int]{Key: v % 3, Value: v}
+	})
+	expected := mustCollect(t, pairs)
+	calls.Store(0)
+
+	checkpointed, err := pairs.Checkpoint(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 100 {
+		t.Errorf("map ran %d times while checkpointing, want 100", calls.Load())
+	}
+
+	for range 2 {
+		if got := mustCollect(t, checkpointed); !slices.Equal(got, expected) {
+			t.Errorf("result = %v, want %v", got, expected)
+		}
+	}
+	if calls.Load() != 100 {
+		t.Errorf("map ran %d times after checkpointing, want 100", calls.Load())
+	}
+
+	if got, want := checkpointed.ToDebugString(), "(4) Checkpoint\n"; got != want {
+		t.Errorf("debug string = %q, want %q", got, want)
+	}
+	if checkpointed.NumPartitions() != pairs.NumPartitions() {
+		t.Errorf("partitions = %d, want %d", checkpointed.NumPartitions(), pairs.NumPartitions())
+	}
+}
+
+// TestCheckpointInterfaceElements verifies checkpointing an RDD whose
+// elements are interfaces holding values of different types.
+func TestCheckpointInterfaceElements(t *testing.T) {
+	ctx := context.Background()
+	data := []any{1, "two", 3.5, true}
+	r, _ := rdd.NewWithPartitions(data, 2)
+
+	checkpointed, err := r.Checkpoint(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mustCollect(t, checkpointed); !slices.Equal(got, data) {
+		t.Errorf("result = %v, want %v", got, data)
+	}
+}
+
+// TestCheckpointDir verifies resolving the checkpoint directory.
+func TestCheckpointDir(t *testing.T) {
+	r := rdd.New([]string{"a", "b"})
+
+	_, err := r.Checkpoint(context.Background(), "")
+	if !errors.Is(err, rdd.ErrNoCheckpointDir) {
+		t.Errorf("expected error %v, got %v", rdd.ErrNoCheckpointDir, err)
+	}
+
+	ctx := rdd.WithCheckpointDir(context.Background(), t.TempDir())
+	checkpointed, err := r.Checkpoint(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mustCollect(t, checkpointed); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("result = %v, want [a b]", got)
+	}
+}
+
+// countingCodec is a gob codec counting the elements it encodes.
+type countingCodec struct {
+	encoded atomic.Int64
+}
+
+func (c *countingCodec) NewEncoder(w io.Writer) rdd.Encoder {
+	return countingEncoder{enc: gob.NewEncoder(w), encoded: &c.encoded}
+}
+
+func (c *countingCodec) NewDecoder(r io.Reader) rdd.Decoder {
+	return gob.NewDecoder(r)
+}
+
+type countingEncoder struct {
+	enc     *gob.Encoder
+	encoded *atomic.Int64
+}
+
+func (e countingEncoder) Encode(v any) error {
+	e.encoded.Add(1)
+	return e.enc.Encode(v)
+}
+
+// TestCheckpointCodec verifies that checkpoints use the codec of the context.
+func TestCheckpointCodec(t *testing.T) {
+	codec := &countingCodec{}
+	ctx := rdd.WithCodec(context.Background(), codec)
+
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4, 5}, 2)
+	checkpointed, err := r.Checkpoint(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if codec.encoded.Load() != 5 {
+		t.Errorf("codec encoded %d elements, want 5", codec.encoded.Load())
+	}
+	if got := mustCollect(t, checkpointed); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("result = %v, want [1 2 3 4 5]", got)
+	}
+}
+
+// TestCheckpointCancellation verifies that a canceled context aborts the
+// checkpoint job.
+func TestCheckpointCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := rdd.New([]int{1, 2, 3}).Checkpoint(ctx, t.TempDir())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got: %v", err)
+	}
+}
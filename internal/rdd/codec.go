@@ -0,0 +1,94 @@
+package rdd
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+	"iter"
+	"os"
+)
+
+// Codec serializes the elements of RDD partitions written to disk, such as
+// checkpoints. Elements are written one after another to a single stream per
+// partition and read back in the same order.
+type Codec interface {
+	// NewEncoder returns an encoder writing elements to w.
+	NewEncoder(w io.Writer) Encoder
+	// NewDecoder returns a decoder reading elements written by an encoder
+	// of the same codec from r.
+	NewDecoder(r io.Reader) Decoder
+}
+
+// Encoder writes a stream of elements.
+type Encoder interface {
+	Encode(v any) error
+}
+
+// Decoder reads a stream of elements. Decode returns io.EOF once the stream
+// is exhausted.
+type Decoder interface {
+	Decode(v any) error
+}
+
+// GobCodec is a Codec using encoding/gob. Concrete types stored in interface
+// elements must be registered with gob.Register.
+type GobCodec struct{}
+
+// NewEncoder returns a gob encoder writing to w.
+func (GobCodec) NewEncoder(w io.Writer) Encoder {
+	return gob.NewEncoder(w)
+}
+
+// NewDecoder returns a gob decoder reading from r.
+func (GobCodec) NewDecoder(r io.Reader) Decoder {
+	return gob.NewDecoder(r)
+}
+
+// encodeFile writes the elements of data to a new file at path. The file is
+// removed if writing fails.
+func encodeFile[T any](path string, codec Codec, data iter.Seq[T]) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	enc := codec.NewEncoder(f)
+	for v := range data {
+		if err = enc.Encode(&v); err != nil {
+			break
+		}
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
+
+// decodeFile reads the elements of a file written by encodeFile and passes
+// them to yield until it returns false or the file ends.
+func decodeFile[T any](path string, codec Codec, yield func(T) bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := codec.NewDecoder(f)
+	for {
+		// Decode into a new value each time, since gob leaves fields
+		// holding zero values untouched.
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if !yield(v) {
+			return nil
+		}
+	}
+}
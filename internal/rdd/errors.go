@@ -0,0 +1,74 @@
+// Package rdd provides errors used throughout the rdd package.
+package rdd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for common rdd operations.
+var (
+	// ErrInvalidPartitions is returned when a partition count is not positive.
+	ErrInvalidPartitions = errors.New("invalid number of partitions")
+
+	// ErrEmptyRDD is returned when an action requires at least one element.
+	ErrEmptyRDD = errors.New("rdd is empty")
+
+	// ErrInvalidFraction is returned when a sampling fraction is out of range.
+	ErrInvalidFraction = errors.New("invalid sample fraction")
+
+	// ErrSampleTooSmall is returned when TakeSample can't draw enough elements.
+	ErrSampleTooSmall = errors.New("sample too small")
+
+	// ErrNoCheckpointDir is returned when no checkpoint directory is configured.
+	ErrNoCheckpointDir = errors.New("checkpoint directory not set")
+
+	// ErrZipMismatch is returned when zipped RDDs are not partitioned alike.
+	ErrZipMismatch = errors.New("rdds have different partitioning")
+
+	// ErrInvalidBuckets is returned when histogram buckets are malformed.
+	ErrInvalidBuckets = errors.New("invalid histogram buckets")
+)
+
+// TaskError reports the failure of the task computing one partition, either
+// because a user function returned an error or because it panicked.
+type TaskError struct {
+	// Partition is the partition the task was computing.
+	Partition int
+	// Index is the position within the partition of the element being
+	// processed, or -1 when it is not known.
+	Index int
+	// Err is the error returned by the user function, or the panic value
+	// when it is an error.
+	Err error
+	// Panic is the recovered panic value, or nil if the task did not panic.
+	Panic any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+	// Attempts is the number of times the task ran before failing the job.
+	Attempts int
+}
+
+// Error returns a description of the failure.
+func (e *TaskError) Error() string {
+	var cause string
+	if e.Panic != nil {
+		cause = fmt.Sprintf("panic: %v", e.Panic)
+	} else {
+		cause = e.Err.Error()
+	}
+
+	msg := fmt.Sprintf("task failed on partition %d", e.Partition)
+	if e.Index >= 0 {
+		msg += fmt.Sprintf(" at element %d", e.Index)
+	}
+	if e.Attempts > 1 {
+		msg += fmt.Sprintf(" after %d attempts", e.Attempts)
+	}
+	return msg + ": " + cause
+}
+
+// Unwrap returns the underlying error.
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
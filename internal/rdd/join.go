@@ -0,0 +1,222 @@
+package rdd
+
+import (
+	"context"
+	"iter"
+)
+
+// Joined holds the values matched for one key by a join.
+type Joined[L, R any] struct {
+	Left  L
+	Right R
+}
+
+// Option is a value that may be missing, used by outer joins for keys that
+// only exist on one side.
+type Option[T any] struct {
+	Value T
+	Valid bool
+}
+
+// CoGrouped holds all the values of one key from both sides of a CoGroup.
+type CoGrouped[V, W any] struct {
+	Left  []V
+	Right []W
+}
+
+// CoGroup groups the values of each key from both RDDs. Both sides are
+// hash-partitioned by key through their own shuffle, so they are processed in
+// parallel. The result has as many partitions as the larger input.
+//
+// Returns the context error if the context is already canceled.
+func CoGroup[K comparable, V, W any](ctx context.Context, left *RDD[Pair[K, V]], right *RDD[Pair[K, W]]) (*RDD[Pair[K, CoGrouped[V, W]]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return coGroup(left, right), nil
+}
+
+// Join returns the pairs of values with matching keys in both RDDs.
+// A key with n values on the left and m values on the right yields n*m pairs.
+//
+// Returns the context error if the context is already canceled.
+func Join[K comparable, V, W any](ctx context.Context, left *RDD[Pair[K, V]], right *RDD[Pair[K, W]]) (*RDD[Pair[K, Joined[V, W]]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return flatMapGroups(coGroup(left, right), "Join", func(g CoGrouped[V, W], yield func(Joined[V, W]) bool) bool {
+		for _, v := range g.Left {
+			for _, w := range g.Right {
+				if !yield(Joined[V, W]{Left: v, Right: w}) {
+					return false
+				}
+			}
+		}
+		return true
+	}), nil
+}
+
+// LeftOuterJoin returns every value of the left RDD paired with each matching
+// right value, or with an invalid Option when the key is missing on the right.
+//
+// Returns the context error if the context is already canceled.
+func LeftOuterJoin[K comparable, V, W any](ctx context.Context, left *RDD[Pair[K, V]], right *RDD[Pair[K, W]]) (*RDD[Pair[K, Joined[V, Option[W]]]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return flatMapGroups(coGroup(left, right), "LeftOuterJoin", func(g CoGrouped[V, W], yield func(Joined[V, Option[W]]) bool) bool {
+		rights := optionals(g.Right)
+		for _, v := range g.Left {
+			for _, w := range rights {
+				if !yield(Joined[V, Option[W]]{Left: v, Right: w}) {
+					return false
+				}
+			}
+		}
+		return true
+	}), nil
+}
+
+// RightOuterJoin returns every value of the right RDD paired with each
+// matching left value, or with an invalid Option when the key is missing on
+// the left.
+//
+// Returns the context error if the context is already canceled.
+func RightOuterJoin[K comparable, V, W any](ctx context.Context, left *RDD[Pair[K, V]], right *RDD[Pair[K, W]]) (*RDD[Pair[K, Joined[Option[V], W]]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return flatMapGroups(coGroup(left, right), "RightOuterJoin", func(g CoGrouped[V, W], yield func(Joined[Option[V], W]) bool) bool {
+		lefts := optionals(g.Left)
+		for _, v := range lefts {
+			for _, w := range g.Right {
+				if !yield(Joined[Option[V], W]{Left: v, Right: w}) {
+					return false
+				}
+			}
+		}
+		return true
+	}), nil
+}
+
+// FullOuterJoin returns the pairs of values with matching keys, plus the
+// values of keys that only exist on one side paired with an invalid Option.
+//
+// Returns the context error if the context is already canceled.
+func FullOuterJoin[K comparable, V, W any](ctx context.Context, left *RDD[Pair[K, V]], right *RDD[Pair[K, W]]) (*RDD[Pair[K, Joined[Option[V], Option[W]]]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return flatMapGroups(coGroup(left, right), "FullOuterJoin", func(g CoGrouped[V, W], yield func(Joined[Option[V], Option[W]]) bool) bool {
+		lefts, rights := optionals(g.Left), optionals(g.Right)
+		for _, v := range lefts {
+			for _, w := range rights {
+				if !yield(Joined[Option[V], Option[W]]{Left: v, Right: w}) {
+					return false
+				}
+			}
+		}
+		return true
+	}), nil
+}
+
+// coGroup builds the RDD behind CoGroup and the joins.
+func coGroup[K comparable, V, W any](left *RDD[Pair[K, V]], right *RDD[Pair[K, W]]) *RDD[Pair[K, CoGrouped[V, W]]] {
+	numParts := max(left.numParts, right.numParts)
+	partitioner := newHashPartitioner[K](numParts)
+
+	leftShuffle := newShuffle(left, numParts, hashSplit[K, V](partitioner))
+	rightShuffle := newShuffle(right, numParts, hashSplit[K, W](partitioner))
+
+	return &RDD[Pair[K, CoGrouped[V, W]]]{
+		op:       "CoGroup",
+		numParts: numParts,
+		compute: func(t *task, split int) iter.Seq[Pair[K, CoGrouped[V, W]]] {
+			return func(yield func(Pair[K, CoGrouped[V, W]]) bool) {
+				index := make(map[K]int)
+				var groups []Pair[K, CoGrouped[V, W]]
+				group := func(key K) *CoGrouped[V, W] {
+					i, ok := index[key]
+					if !ok {
+						i = len(groups)
+						index[key] = i
+						groups = append(groups, Pair[K, CoGrouped[V, W]]{Key: key})
+					}
+					return &groups[i].Value
+				}
+
+				for p := range leftShuffle.read(t, split) {
+					g := group(p.Key)
+					g.Left = append(g.Left, p.Value)
+				}
+				for p := range rightShuffle.read(t, split) {
+					g := group(p.Key)
+					g.Right = append(g.Right, p.Value)
+				}
+
+				for _, g := range groups {
+					if !yield(g) {
+						return
+					}
+				}
+			}
+		},
+		deps: []dependency{leftShuffle.dependency(), rightShuffle.dependency()},
+	}
+}
+
+// hashSplit returns a shuffle split function that buckets pairs by key.
+func hashSplit[K comparable, V any](partitioner hashPartitioner[K]) func(*task, iter.Seq[Pair[K, V]]) [][]Pair[K, V] {
+	return func(_ *task, it iter.Seq[Pair[K, V]]) [][]Pair[K, V] {
+		buckets := make([][]Pair[K, V], partitioner.numParts)
+		for p := range it {
+			bucket := partitioner.partition(p.Key)
+			buckets[bucket] = append(buckets[bucket], p)
+		}
+		return buckets
+	}
+}
+
+// flatMapGroups expands each co-grouped key into zero or more joined values.
+// fn returns false when yield asked to stop.
+func flatMapGroups[K comparable, V, W, U any](
+	r *RDD[Pair[K, CoGrouped[V, W]]],
+	op string,
+	fn func(g CoGrouped[V, W], yield func(U) bool) bool,
+) *RDD[Pair[K, U]] {
+	return narrow(r, op, func(_ *task, in iter.Seq[Pair[K, CoGrouped[V, W]]]) iter.Seq[Pair[K, U]] {
+		return func(yield func(Pair[K, U]) bool) {
+			for p := range in {
+				ok := fn(p.Value, func(u U) bool {
+					return yield(Pair[K, U]{Key: p.Key, Value: u})
+				})
+				if !ok {
+					return
+				}
+			}
+		}
+	})
+}
+
+// optionals wraps values as valid options, or returns a single invalid option
+// when there are none so that outer joins still emit the other side.
+func optionals[T any](values []T) []Option[T] {
+	if len(values) == 0 {
+		return []Option[T]{{}}
+	}
+	opts := make([]Option[T], len(values))
+	for i, v := range values {
+		opts[i] = Option[T]{Value: v, Valid: true}
+	}
+	return opts
+}
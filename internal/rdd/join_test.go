@@ -0,0 +1,143 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// formatPairs renders collected pairs as sorted strings for comparison.
+func formatPairs[K comparable, V any](t *testing.T, r *rdd.RDD[rdd.Pair[K, V]]) []string {
+	t.Helper()
+	var out []string
+	for _, p := range mustCollect(t, r) {
+		out = append(out, fmt.Sprintf("%v=%v", p.Key, p.Value))
+	}
+	slices.Sort(out)
+	return out
+}
+
+// TestJoin verifies the inner join.
+func TestJoin(t *testing.T) {
+	events, _ := rdd.NewWithPartitions([]rdd.Pair[int, string]{
+		{Key: 1, Value: "login"}, {Key: 2, Value: "click"}, {Key: 1, Value: "logout"}, {Key: 3, Value: "view"},
+	}, 3)
+	users, _ := rdd.NewWithPartitions([]rdd.Pair[int, string]{
+		{Key: 1, Value: "ann"}, {Key: 2, Value: "bob"}, {Key: 4, Value: "eve"},
+	}, 2)
+
+	joined, err := rdd.Join(context.Background(), events, users)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if joined.NumPartitions() != 3 {
+		t.Errorf("partitions = %d, want 3", joined.NumPartitions())
+	}
+
+	expected := []string{"1={login ann}", "1={logout ann}", "2={click bob}"}
+	if got := formatPairs(t, joined); !slices.Equal(got, expected) {
+		t.Errorf("joined = %v, want %v", got, expected)
+	}
+}
+
+// TestOuterJoins verifies left, right and full outer joins.
+func TestOuterJoins(t *testing.T) {
+	ctx := context.Background()
+	events, _ := rdd.NewWithPartitions([]rdd.Pair[int, string]{
+		{Key: 1, Value: "login"}, {Key: 2, Value: "click"}, {Key: 1, Value: "logout"}, {Key: 3, Value: "view"},
+	}, 3)
+	users, _ := rdd.NewWithPartitions([]rdd.Pair[int, string]{
+		{Key: 1, Value: "ann"}, {Key: 2, Value: "bob"}, {Key: 4, Value: "eve"},
+	}, 2)
+
+	left, err := rdd.LeftOuterJoin(ctx, events, users)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"1={login {ann true}}", "1={logout {ann true}}", "2={click {bob true}}", "3={view { false}}"}
+	if got := formatPairs(t, left); !slices.Equal(got, expected) {
+		t.Errorf("left outer = %v, want %v", got, expected)
+	}
+
+	right, err := rdd.RightOuterJoin(ctx, events, users)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = []string{"1={{login true} ann}", "1={{logout true} ann}", "2={{click true} bob}", "4={{ false} eve}"}
+	if got := formatPairs(t, right); !slices.Equal(got, expected) {
+		t.Errorf("right outer = %v, want %v", got, expected)
+	}
+
+	full, err := rdd.FullOuterJoin(ctx, events, users)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = []string{
+		"1={{login true} {ann true}}",
+		"1={{logout true} {ann true}}",
+		"2={{click true} {bob true}}",
+		"3={{view true} { false}}",
+		"4={{ false} {eve true}}",
+	}
+	if got := formatPairs(t, full); !slices.Equal(got, expected) {
+		t.Errorf("full outer = %v, want %v", got, expected)
+	}
+}
+
+// TestCoGroup verifies grouping both sides by key.
+func TestCoGroup(t *testing.T) {
+	events, _ := rdd.NewWithPartitions([]rdd.Pair[int, string]{
+		{Key: 1, Value: "login"}, {Key: 2, Value: "click"}, {Key: 1, Value: "logout"}, {Key: 3, Value: "view"},
+	}, 3)
+	users, _ := rdd.NewWithPartitions([]rdd.Pair[int, string]{
+		{Key: 1, Value: "ann"}, {Key: 2, Value: "bob"}, {Key: 4, Value: "eve"},
+	}, 2)
+
+	grouped, err := rdd.CoGroup(context.Background(), events, users)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := toMap(t, grouped)
+	if len(got) != 4 {
+		t.Fatalf("keys = %d, want 4", len(got))
+	}
+	if !slices.Equal(got[1].Left, []string{"login", "logout"}) || !slices.Equal(got[1].Right, []string{"ann"}) {
+		t.Errorf("group 1 = %+v", got[1])
+	}
+	if len(got[3].Right) != 0 || len(got[4].Left) != 0 {
+		t.Errorf("unexpected values for one-sided keys: 3=%+v 4=%+v", got[3], got[4])
+	}
+
+	l := grouped.Lineage()
+	if !l.Shuffle || len(l.Parents) != 2 {
+		t.Errorf("lineage = %+v, want two shuffled parents", l)
+	}
+}
+
+// TestJoinCancellation verifies that joins respect context cancellation.
+func TestJoinCancellation(t *testing.T) {
+	events, _ := rdd.NewWithPartitions([]rdd.Pair[int, string]{
+		{Key: 1, Value: "login"}, {Key: 2, Value: "click"}, {Key: 1, Value: "logout"}, {Key: 3, Value: "view"},
+	}, 3)
+	users, _ := rdd.NewWithPartitions([]rdd.Pair[int, string]{
+		{Key: 1, Value: "ann"}, {Key: 2, Value: "bob"}, {Key: 4, Value: "eve"},
+	}, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel immediately.
+
+	if _, err := rdd.Join(ctx, events, users); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got: %v", err)
+	}
+
+	joined, _ := rdd.Join(context.Background(), events, users)
+	if _, err := joined.Collect(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got: %v", err)
+	}
+}
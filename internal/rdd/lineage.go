@@ -0,0 +1,93 @@
+package rdd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Lineage describes how an RDD is derived from its parents.
+// It forms a DAG whose leaves are the source RDDs.
+type Lineage struct {
+	// Op names the operation that produced the RDD, e.g. "Map".
+	Op string
+	// Partitions is the number of partitions of the RDD.
+	Partitions int
+	// Shuffle reports whether the RDD reads its parents through a shuffle,
+	// which ends the fused pass over the parents and starts a new stage.
+	Shuffle bool
+	// Storage is the level the RDD is persisted with, or StorageNone.
+	Storage StorageLevel
+	// Parents are the RDDs this RDD is computed from.
+	Parents []*Lineage
+}
+
+// dependency links an RDD to one of its parents. Narrow dependencies compute
+// a partition from parent partitions in the same pass, while shuffle
+// dependencies read the output of a stage that has to run first.
+type dependency struct {
+	parent node
+	stage  stage // nil for narrow dependencies
+}
+
+// node is the type-erased view of an RDD used to walk lineage graphs.
+type node interface {
+	lineage() *Lineage
+	prepare(ctx context.Context) error
+}
+
+// lineage builds the lineage graph rooted at r.
+func (r *RDD[T]) lineage() *Lineage {
+	l := &Lineage{
+		Op:         r.op,
+		Partitions: r.numParts,
+		Storage:    r.StorageLevel(),
+	}
+	for _, dep := range r.deps {
+		l.Parents = append(l.Parents, dep.parent.lineage())
+		if dep.stage != nil {
+			l.Shuffle = true
+		}
+	}
+	return l
+}
+
+// Lineage returns the lineage graph of the RDD, describing the
+// transformations that will execute when an action runs.
+func (r *RDD[T]) Lineage() *Lineage {
+	return r.lineage()
+}
+
+// ToDebugString returns a human-readable description of the RDD lineage,
+// one transformation per line starting with the RDD itself. Transformations
+// prefixed with "|" are fused with the line above into a single pass, while
+// "+-" marks a shuffle boundary that starts a new stage. Persisted RDDs are
+// followed by their storage level in brackets.
+func (r *RDD[T]) ToDebugString() string {
+	var b strings.Builder
+	l := r.lineage()
+	fmt.Fprintf(&b, "(%d) %s\n", l.Partitions, l.label())
+	writeParents(&b, l, "")
+	return b.String()
+}
+
+// writeParents writes the parents of l to b, indented by prefix.
+func writeParents(b *strings.Builder, l *Lineage, prefix string) {
+	for _, parent := range l.Parents {
+		if l.Shuffle {
+			fmt.Fprintf(b, "%s +-(%d) %s\n", prefix, parent.Partitions, parent.label())
+			writeParents(b, parent, prefix+"    ")
+			continue
+		}
+		fmt.Fprintf(b, "%s |  %s\n", prefix, parent.label())
+		writeParents(b, parent, prefix)
+	}
+}
+
+// label returns the operation of l followed by its storage level, if any.
+func (l *Lineage) label() string {
+	if l.Storage == StorageNone {
+		return l.Op
+	}
+	return fmt.Sprintf("%s [%s]", l.Op, l.Storage)
+}
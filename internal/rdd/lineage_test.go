@@ -0,0 +1,125 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// TestLazyEvaluation verifies that transformations only run when an action executes.
+func TestLazyEvaluation(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int64
+
+	r := rdd.New([]int{1, 2, 3, 4, 5})
+	mapped, err := r.Map(ctx, func(n int) int {
+		calls.Add(1)
+		return n * 2
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	filtered, err := mapped.Filter(ctx, func(n int) bool {
+		return n > 4
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls.Load() != 0 {
+		t.Errorf("map ran %d times before an action, want 0", calls.Load())
+	}
+
+	count, err := filtered.Count(ctx)
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("count = %d, want 3", count)
+	}
+	if calls.Load() != 5 {
+		t.Errorf("map ran %d times, want 5", calls.Load())
+	}
+}
+
+// TestLineage verifies the recorded lineage graph.
+func TestLineage(t *testing.T) {
+	ctx := context.Background()
+
+	r := rdd.New([]int{1, 2, 3})
+	mapped, _ := r.Map(ctx, func(n int) int { return n + 1 })
+	filtered, _ := mapped.Filter(ctx, func(n int) bool { return n%2 == 0 })
+	words, _ := rdd.FlatMap(ctx, filtered, func(n int) []string {
+		return []string{strings.Repeat("x", n)}
+	})
+
+	expected := []string{"FlatMap", "Filter", "Map", "New"}
+	l := words.Lineage()
+	for i, op := range expected {
+		if l == nil {
+			t.Fatalf("lineage ended at depth %d, want %d steps", i, len(expected))
+		}
+		if l.Op != op {
+			t.Errorf("lineage[%d] = %s, want %s", i, l.Op, op)
+		}
+		if len(l.Parents) > 0 {
+			l = l.Parents[0]
+		} else {
+			l = nil
+		}
+	}
+	if l != nil {
+		t.Errorf("unexpected lineage step %s", l.Op)
+	}
+}
+
+// TestToDebugString verifies the textual lineage description.
+func TestToDebugString(t *testing.T) {
+	ctx := context.Background()
+
+	r := rdd.New([]int{1, 2, 3})
+	mapped, _ := r.Map(ctx, func(n int) int { return n + 1 })
+	filtered, _ := mapped.Filter(ctx, func(n int) bool { return n%2 == 0 })
+
+	lines := strings.Split(strings.TrimSpace(filtered.ToDebugString()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("lines = %d, want 3:\n%s", len(lines), filtered.ToDebugString())
+	}
+	if !strings.HasSuffix(lines[0], ") Filter") {
+		t.Errorf("line[0] = %q, want Filter", lines[0])
+	}
+	if lines[1] != " |  Map" {
+		t.Errorf("line[1] = %q, want %q", lines[1], " |  Map")
+	}
+	if lines[2] != " |  New" {
+		t.Errorf("line[2] = %q, want %q", lines[2], " |  New")
+	}
+}
+
+// TestCollectCancellation verifies that actions respect context cancellation.
+func TestCollectCancellation(t *testing.T) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = i
+	}
+
+	r := rdd.New(data)
+	mapped, err := r.Map(context.Background(), func(n int) int { return n * 2 })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel immediately.
+
+	if _, err = mapped.Collect(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got: %v", err)
+	}
+	if _, err = mapped.Count(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got: %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package rdd
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// Pair is a key-value element of a pair RDD.
+// Pair RDDs support key-based operations such as ReduceByKey and joins.
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// CombineByKey combines the values of each key into a single value of type C.
+// Values are first combined within each partition (createCombiner starts a
+// combiner for the first value of a key, mergeValue adds further values), then
+// hash-shuffled by key and merged across partitions with mergeCombiners.
+// The result has the same number of partitions as r.
+//
+// Shuffled combiners are kept for later jobs, so mergeCombiners must return
+// a new combiner rather than modify its arguments, for instance by using
+// slices.Concat instead of append when combiners are slices.
+//
+// Returns the context error if the context is already canceled.
+func CombineByKey[K comparable, V, C any](
+	ctx context.Context,
+	r *RDD[Pair[K, V]],
+	createCombiner func(V) C,
+	mergeValue func(C, V) C,
+	mergeCombiners func(C, C) C,
+) (*RDD[Pair[K, C]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	identity := func(c C) C { return c }
+	return combineByKey(r, "CombineByKey", createCombiner, mergeValue, mergeCombiners, identity), nil
+}
+
+// ReduceByKey merges the values of each key using fn, which must be
+// commutative and associative. Values are reduced within each partition
+// before being shuffled, so only one value per key and partition moves.
+//
+// Returns the context error if the context is already canceled.
+func ReduceByKey[K comparable, V any](ctx context.Context, r *RDD[Pair[K, V]], fn func(V, V) V) (*RDD[Pair[K, V]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	identity := func(v V) V { return v }
+	return combineByKey(r, "ReduceByKey", identity, fn, fn, identity), nil
+}
+
+// GroupByKey groups the values of each key into a single slice.
+// Values of a key keep the order in which they appear in r.
+//
+// Returns the context error if the context is already canceled.
+func GroupByKey[K comparable, V any](ctx context.Context, r *RDD[Pair[K, V]]) (*RDD[Pair[K, []V]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return combineByKey(r, "GroupByKey",
+		func(v V) []V { return []V{v} },
+		func(group []V, v V) []V { return append(group, v) },
+		func(a, b []V) []V { return append(a, b...) },
+		slices.Clone[[]V],
+	), nil
+}
+
+// MapValues applies fn to the value of each pair, keeping the keys and the
+// partitioning of r.
+//
+// Returns the context error if the context is already canceled.
+func MapValues[K comparable, V, U any](ctx context.Context, r *RDD[Pair[K, V]], fn func(V) U) (*RDD[Pair[K, U]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return narrow(r, "MapValues", func(_ *task, in iter.Seq[Pair[K, V]]) iter.Seq[Pair[K, U]] {
+		return func(yield func(Pair[K, U]) bool) {
+			for p := range in {
+				if !yield(Pair[K, U]{Key: p.Key, Value: fn(p.Value)}) {
+					return
+				}
+			}
+		}
+	}), nil
+}
+
+// Keys returns an RDD of the keys of each pair.
+//
+// Returns the context error if the context is already canceled.
+func Keys[K comparable, V any](ctx context.Context, r *RDD[Pair[K, V]]) (*RDD[K], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return narrow(r, "Keys", func(_ *task, in iter.Seq[Pair[K, V]]) iter.Seq[K] {
+		return func(yield func(K) bool) {
+			for p := range in {
+				if !yield(p.Key) {
+					return
+				}
+			}
+		}
+	}), nil
+}
+
+// Values returns an RDD of the values of each pair.
+//
+// Returns the context error if the context is already canceled.
+func Values[K comparable, V any](ctx context.Context, r *RDD[Pair[K, V]]) (*RDD[V], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return narrow(r, "Values", func(_ *task, in iter.Seq[Pair[K, V]]) iter.Seq[V] {
+		return func(yield func(V) bool) {
+			for p := range in {
+				if !yield(p.Value) {
+					return
+				}
+			}
+		}
+	}), nil
+}
+
+// CountByKey returns the number of pairs for each key.
+// This is an action: counts are computed per partition and merged once all
+// tasks complete, so the number of distinct keys should be small.
+//
+// Returns context.Canceled if the context is canceled during processing.
+func CountByKey[K comparable, V any](ctx context.Context, r *RDD[Pair[K, V]]) (map[K]int, error) {
+	partials, err := runJob(ctx, r, func(_ *task, it iter.Seq[Pair[K, V]]) map[K]int {
+		counts := make(map[K]int)
+		for p := range it {
+			counts[p.Key]++
+		}
+		return counts
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[K]int)
+	for _, partial := range partials {
+		for key, n := range partial {
+			counts[key] += n
+		}
+	}
+	return counts, nil
+}
+
+// combineByKey builds the shuffled RDD behind the *ByKey operations.
+// The shuffle blocks are kept for later jobs and may be read concurrently,
+// so on the reduce side the first combiner of each key is passed through
+// clone before further combiners are merged into it.
+func combineByKey[K comparable, V, C any](
+	r *RDD[Pair[K, V]],
+	op string,
+	createCombiner func(V) C,
+	mergeValue func(C, V) C,
+	mergeCombiners func(C, C) C,
+	clone func(C) C,
+) *RDD[Pair[K, C]] {
+	partitioner := newHashPartitioner[K](r.numParts)
+
+	s := newShuffle(r, r.numParts, func(_ *task, it iter.Seq[Pair[K, V]]) [][]Pair[K, C] {
+		buckets := make([][]Pair[K, C], r.numParts)
+		for _, p := range combineValues(it, createCombiner, mergeValue) {
+			bucket := partitioner.partition(p.Key)
+			buckets[bucket] = append(buckets[bucket], p)
+		}
+		return buckets
+	})
+
+	return &RDD[Pair[K, C]]{
+		op:       op,
+		numParts: r.numParts,
+		compute: func(t *task, split int) iter.Seq[Pair[K, C]] {
+			return func(yield func(Pair[K, C]) bool) {
+				for _, p := range combineValues(s.read(t, split), clone, mergeCombiners) {
+					if !yield(p) {
+						return
+					}
+				}
+			}
+		},
+		deps: []dependency{s.dependency()},
+	}
+}
+
+// combineValues combines the values of each key in pairs, returning one pair
+// per key in the order the keys were first seen.
+func combineValues[K comparable, V, C any](pairs iter.Seq[Pair[K, V]], create func(V) C, merge func(C, V) C) []Pair[K, C] {
+	index := make(map[K]int)
+	var combined []Pair[K, C]
+	for p := range pairs {
+		if i, ok := index[p.Key]; ok {
+			combined[i].Value = merge(combined[i].Value, p.Value)
+			continue
+		}
+		index[p.Key] = len(combined)
+		combined = append(combined, Pair[K, C]{Key: p.Key, Value: create(p.Value)})
+	}
+	return combined
+}
@@ -0,0 +1,253 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// toMap collects a pair RDD into a map, failing on duplicate keys.
+func toMap[K comparable, V any](t *testing.T, r *rdd.RDD[rdd.Pair[K, V]]) map[K]V {
+	t.Helper()
+	result := make(map[K]V)
+	for _, p := range mustCollect(t, r) {
+		if _, ok := result[p.Key]; ok {
+			t.Fatalf("duplicate key %v", p.Key)
+		}
+		result[p.Key] = p.Value
+	}
+	return result
+}
+
+// TestReduceByKey verifies per-key reduction across partitions.
+func TestReduceByKey(t *testing.T) {
+	ctx := context.Background()
+	r, _ := rdd.NewWithPartitions([]rdd.Pair[string, int]{
+		{Key: "a", Value: 1}, {Key: "b", Value: 1}, {Key: "a", Value: 1}, {Key: "c", Value: 1},
+		{Key: "b", Value: 1}, {Key: "a", Value: 1}, {Key: "d", Value: 1}, {Key: "a", Value: 1},
+	}, 3)
+
+	counts, err := rdd.ReduceByKey(ctx, r, func(a, b int) int { return a + b })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if counts.NumPartitions() != 3 {
+		t.Errorf("partitions = %d, want 3", counts.NumPartitions())
+	}
+
+	expected := map[string]int{"a": 4, "b": 2, "c": 1, "d": 1}
+	if got := toMap(t, counts); !maps.Equal(got, expected) {
+		t.Errorf("counts = %v, want %v", got, expected)
+	}
+}
+
+// TestGroupByKey verifies grouping values by key.
+func TestGroupByKey(t *testing.T) {
+	ctx := context.Background()
+
+	r, _ := rdd.NewWithPartitions([]rdd.Pair[string, int]{
+		{Key: "x", Value: 1},
+		{Key: "y", Value: 2},
+		{Key: "x", Value: 3},
+		{Key: "x", Value: 4},
+	}, 2)
+
+	grouped, err := rdd.GroupByKey(ctx, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := toMap(t, grouped)
+	if !slices.Equal(got["x"], []int{1, 3, 4}) {
+		t.Errorf("group x = %v, want [1 3 4]", got["x"])
+	}
+	if !slices.Equal(got["y"], []int{2}) {
+		t.Errorf("group y = %v, want [2]", got["y"])
+	}
+}
+
+// TestGroupByKeyRecollect verifies that collected groups don't share memory
+// with the shuffle blocks kept for later jobs.
+func TestGroupByKeyRecollect(t *testing.T) {
+	ctx := context.Background()
+
+	r, _ := rdd.NewWithPartitions([]rdd.Pair[string, int]{
+		{Key: "a", Value: 1}, {Key: "a", Value: 2}, {Key: "a", Value: 3}, {Key: "b", Value: 1},
+		{Key: "a", Value: 4}, {Key: "b", Value: 2}, {Key: "b", Value: 3}, {Key: "b", Value: 4},
+	}, 2)
+
+	grouped, err := rdd.GroupByKey(ctx, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first := mustCollect(t, grouped)
+	for _, p := range first {
+		p.Value[0] = 999
+	}
+
+	got := toMap(t, grouped)
+	for _, key := range []string{"a", "b"} {
+		if !slices.Equal(got[key], []int{1, 2, 3, 4}) {
+			t.Errorf("group %s = %v, want [1 2 3 4]", key, got[key])
+		}
+	}
+}
+
+// TestGroupByKeyConcurrentCollect verifies collecting the same grouped RDD
+// from several goroutines.
+func TestGroupByKeyConcurrentCollect(t *testing.T) {
+	ctx := context.Background()
+
+	r, _ := rdd.NewWithPartitions([]rdd.Pair[string, int]{
+		{Key: "a", Value: 1}, {Key: "a", Value: 2}, {Key: "a", Value: 3}, {Key: "b", Value: 1},
+		{Key: "a", Value: 4}, {Key: "b", Value: 2}, {Key: "b", Value: 3}, {Key: "b", Value: 4},
+	}, 2)
+
+	grouped, err := rdd.GroupByKey(ctx, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	results := make([][]rdd.Pair[string, []int], 4)
+	errs := make([]error, len(results))
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = grouped.Collect(ctx)
+		}()
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		if errs[i] != nil {
+			t.Fatalf("Collect failed: %v", errs[i])
+		}
+		for _, p := range result {
+			if !slices.Equal(p.Value, []int{1, 2, 3, 4}) {
+				t.Errorf("collect %d: group %s = %v, want [1 2 3 4]", i, p.Key, p.Value)
+			}
+		}
+	}
+}
+
+// TestCombineByKey verifies combining values into a different type.
+func TestCombineByKey(t *testing.T) {
+	ctx := context.Background()
+
+	type avg struct {
+		sum   float64
+		count int
+	}
+
+	r, _ := rdd.NewWithPartitions([]rdd.Pair[string, float64]{
+		{Key: "a", Value: 1},
+		{Key: "b", Value: 10},
+		{Key: "a", Value: 3},
+		{Key: "b", Value: 20},
+		{Key: "a", Value: 5},
+	}, 2)
+
+	combined, err := rdd.CombineByKey(ctx, r,
+		func(v float64) avg { return avg{sum: v, count: 1} },
+		func(acc avg, v float64) avg { return avg{sum: acc.sum + v, count: acc.count + 1} },
+		func(a, b avg) avg { return avg{sum: a.sum + b.sum, count: a.count + b.count} },
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	means, err := rdd.MapValues(ctx, combined, func(a avg) float64 { return a.sum / float64(a.count) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]float64{"a": 3, "b": 15}
+	if got := toMap(t, means); !maps.Equal(got, expected) {
+		t.Errorf("means = %v, want %v", got, expected)
+	}
+}
+
+// TestKeysAndValues verifies projecting keys and values.
+func TestKeysAndValues(t *testing.T) {
+	ctx := context.Background()
+
+	r := rdd.New([]rdd.Pair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}})
+
+	keys, err := rdd.Keys(ctx, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mustCollect(t, keys); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("keys = %v, want [a b]", got)
+	}
+
+	values, err := rdd.Values(ctx, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mustCollect(t, values); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("values = %v, want [1 2]", got)
+	}
+}
+
+// TestCountByKey verifies counting pairs per key.
+func TestCountByKey(t *testing.T) {
+	r, _ := rdd.NewWithPartitions([]rdd.Pair[string, int]{
+		{Key: "a", Value: 1}, {Key: "b", Value: 1}, {Key: "a", Value: 1}, {Key: "c", Value: 1},
+		{Key: "b", Value: 1}, {Key: "a", Value: 1}, {Key: "d", Value: 1}, {Key: "a", Value: 1},
+	}, 3)
+
+	counts, err := rdd.CountByKey(context.Background(), r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]int{"a": 4, "b": 2, "c": 1, "d": 1}
+	if !maps.Equal(counts, expected) {
+		t.Errorf("counts = %v, want %v", counts, expected)
+	}
+}
+
+// TestReduceByKeyLineage verifies that key-based operations shuffle.
+func TestReduceByKeyLineage(t *testing.T) {
+	r, _ := rdd.NewWithPartitions([]rdd.Pair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 1}}, 2)
+
+	counts, _ := rdd.ReduceByKey(context.Background(), r, func(a, b int) int { return a + b })
+
+	l := counts.Lineage()
+	if l.Op != "ReduceByKey" || !l.Shuffle {
+		t.Errorf("lineage = %+v, want shuffled ReduceByKey", l)
+	}
+}
+
+// TestPairCancellation verifies that pair operations respect context cancellation.
+func TestPairCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel immediately.
+
+	r, _ := rdd.NewWithPartitions([]rdd.Pair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 1}}, 2)
+
+	if _, err := rdd.ReduceByKey(ctx, r, func(a, b int) int { return a + b }); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReduceByKey: expected context.Canceled error, got: %v", err)
+	}
+	if _, err := rdd.GroupByKey(ctx, r); !errors.Is(err, context.Canceled) {
+		t.Errorf("GroupByKey: expected context.Canceled error, got: %v", err)
+	}
+	if _, err := rdd.CountByKey(ctx, r); !errors.Is(err, context.Canceled) {
+		t.Errorf("CountByKey: expected context.Canceled error, got: %v", err)
+	}
+
+	grouped, _ := rdd.GroupByKey(context.Background(), r)
+	if _, err := grouped.Collect(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Collect: expected context.Canceled error, got: %v", err)
+	}
+}
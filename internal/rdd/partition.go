@@ -0,0 +1,139 @@
+package rdd
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// NewWithPartitions creates a new RDD from the provided data slice split into
+// n partitions of near-equal size. The data is copied to ensure immutability.
+//
+// Returns ErrInvalidPartitions if n is not positive.
+func NewWithPartitions[T any](data []T, n int) (*RDD[T], error) {
+	if n < 1 {
+		return nil, fmt.Errorf("creating rdd: %w: got %d", ErrInvalidPartitions, n)
+	}
+	return newParallelCollection("NewWithPartitions", data, n), nil
+}
+
+// NumPartitions returns the number of partitions of the RDD.
+// Each partition is processed by a single task.
+func (r *RDD[T]) NumPartitions() int {
+	return r.numParts
+}
+
+// Repartition returns a new RDD with exactly n partitions. Elements are
+// distributed round-robin through a shuffle, which balances skewed
+// partitions at the cost of moving data.
+//
+// Returns ErrInvalidPartitions if n is not positive.
+func (r *RDD[T]) Repartition(n int) (*RDD[T], error) {
+	if n < 1 {
+		return nil, fmt.Errorf("repartitioning rdd: %w: got %d", ErrInvalidPartitions, n)
+	}
+
+	s := newShuffle(r, n, func(t *task, it iter.Seq[T]) [][]T {
+		buckets := make([][]T, n)
+		p := t.partition
+		for v := range it {
+			p = (p + 1) % n
+			buckets[p] = append(buckets[p], v)
+		}
+		return buckets
+	})
+
+	return &RDD[T]{
+		op:       "Repartition",
+		numParts: n,
+		compute:  s.read,
+		deps:     []dependency{s.dependency()},
+	}, nil
+}
+
+// Coalesce returns a new RDD with at most n partitions by merging adjacent
+// partitions without a shuffle. If n is not lower than the current number of
+// partitions the partitioning is kept as is; use Repartition to increase it.
+//
+// Returns ErrInvalidPartitions if n is not positive.
+func (r *RDD[T]) Coalesce(n int) (*RDD[T], error) {
+	if n < 1 {
+		return nil, fmt.Errorf("coalescing rdd: %w: got %d", ErrInvalidPartitions, n)
+	}
+
+	n = min(n, r.numParts)
+	parentParts := r.numParts
+
+	return &RDD[T]{
+		op:       "Coalesce",
+		numParts: n,
+		compute: func(t *task, split int) iter.Seq[T] {
+			start := split * parentParts / n
+			end := (split + 1) * parentParts / n
+			return func(yield func(T) bool) {
+				for parentSplit := start; parentSplit < end; parentSplit++ {
+					for v := range r.iterator(t, parentSplit) {
+						if !yield(v) {
+							return
+						}
+					}
+				}
+			}
+		},
+		deps: []dependency{{parent: r}},
+	}, nil
+}
+
+// Glom returns a new RDD with one element per partition holding all the
+// elements of that partition. It is a function rather than a method because
+// a method returning RDD[[]T] would make RDD infinitely recursive.
+func Glom[T any](r *RDD[T]) *RDD[[]T] {
+	return narrow(r, "Glom", func(_ *task, in iter.Seq[T]) iter.Seq[[]T] {
+		return func(yield func([]T) bool) {
+			part := []T{}
+			for v := range in {
+				part = append(part, v)
+			}
+			yield(part)
+		}
+	})
+}
+
+// MapPartitions transforms each partition of r as a whole: fn receives an
+// iterator over the elements of a partition and returns an iterator over the
+// output elements. Setup that is expensive per element, such as compiling a
+// pattern or opening a connection, can run once per partition in fn, with
+// teardown deferred inside the returned iterator. The context passed to fn is
+// the task context.
+// The transformation is lazy and runs when an action is executed.
+//
+// Returns the context error if the context is already canceled.
+func MapPartitions[T, U any](ctx context.Context, r *RDD[T], fn func(ctx context.Context, it iter.Seq[T]) iter.Seq[U]) (*RDD[U], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return narrow(r, "MapPartitions", func(t *task, in iter.Seq[T]) iter.Seq[U] {
+		return fn(t.ctx, in)
+	}), nil
+}
+
+// MapPartitionsWithIndex is like MapPartitions, but fn also receives the
+// index of the partition.
+//
+// Returns the context error if the context is already canceled.
+func MapPartitionsWithIndex[T, U any](
+	ctx context.Context,
+	r *RDD[T],
+	fn func(ctx context.Context, index int, it iter.Seq[T]) iter.Seq[U],
+) (*RDD[U], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return narrowSplit(r, "MapPartitionsWithIndex", func(t *task, split int, in iter.Seq[T]) iter.Seq[U] {
+		return fn(t.ctx, split, in)
+	}), nil
+}
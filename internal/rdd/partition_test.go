@@ -0,0 +1,265 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// TestNewWithPartitions verifies explicit partitioning of source data.
+func TestNewWithPartitions(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []int
+		numParts  int
+		wantParts [][]int
+		wantErr   error
+	}{
+		{
+			name:      "even split",
+			input:     []int{1, 2, 3, 4},
+			numParts:  2,
+			wantParts: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:      "uneven split",
+			input:     []int{1, 2, 3, 4, 5},
+			numParts:  2,
+			wantParts: [][]int{{1, 2}, {3, 4, 5}},
+		},
+		{
+			name:      "more partitions than elements",
+			input:     []int{1},
+			numParts:  3,
+			wantParts: [][]int{{}, {}, {1}},
+		},
+		{
+			name:     "zero partitions",
+			input:    []int{1, 2},
+			numParts: 0,
+			wantErr:  rdd.ErrInvalidPartitions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := rdd.NewWithPartitions(tt.input, tt.numParts)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if r.NumPartitions() != tt.numParts {
+				t.Errorf("partitions = %d, want %d", r.NumPartitions(), tt.numParts)
+			}
+
+			got := mustCollect(t, rdd.Glom(r))
+			if !slices.EqualFunc(got, tt.wantParts, slices.Equal[[]int]) {
+				t.Errorf("partitions = %v, want %v", got, tt.wantParts)
+			}
+		})
+	}
+}
+
+// TestTransformationsKeepPartitions verifies that narrow transformations
+// operate per partition.
+func TestTransformationsKeepPartitions(t *testing.T) {
+	ctx := context.Background()
+
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4, 5, 6}, 3)
+	mapped, _ := r.Map(ctx, func(n int) int { return n * 10 })
+	filtered, _ := mapped.Filter(ctx, func(n int) bool { return n != 30 })
+
+	if filtered.NumPartitions() != 3 {
+		t.Errorf("partitions = %d, want 3", filtered.NumPartitions())
+	}
+
+	got := mustCollect(t, rdd.Glom(filtered))
+	expected := [][]int{{10, 20}, {40}, {50, 60}}
+	if !slices.EqualFunc(got, expected, slices.Equal[[]int]) {
+		t.Errorf("partitions = %v, want %v", got, expected)
+	}
+}
+
+// TestRepartition verifies shuffling into a new number of partitions.
+func TestRepartition(t *testing.T) {
+	tests := []struct {
+		name     string
+		numParts int
+		wantErr  error
+	}{
+		{name: "increase partitions", numParts: 5},
+		{name: "decrease partitions", numParts: 1},
+		{name: "invalid partitions", numParts: -1, wantErr: rdd.ErrInvalidPartitions},
+	}
+
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	source, _ := rdd.NewWithPartitions(input, 2)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := source.Repartition(tt.numParts)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if r.NumPartitions() != tt.numParts {
+				t.Errorf("partitions = %d, want %d", r.NumPartitions(), tt.numParts)
+			}
+
+			parts := mustCollect(t, rdd.Glom(r))
+			for i, part := range parts {
+				// Round-robin distribution keeps partitions balanced.
+				if len(part) < len(input)/tt.numParts {
+					t.Errorf("partition %d has %d elements, want at least %d", i, len(part), len(input)/tt.numParts)
+				}
+			}
+
+			got := mustCollect(t, r)
+			slices.Sort(got)
+			if !slices.Equal(got, input) {
+				t.Errorf("elements = %v, want %v", got, input)
+			}
+		})
+	}
+}
+
+// TestCoalesce verifies merging partitions without a shuffle.
+func TestCoalesce(t *testing.T) {
+	tests := []struct {
+		name      string
+		numParts  int
+		wantParts [][]int
+		wantErr   error
+	}{
+		{
+			name:      "merge adjacent partitions",
+			numParts:  2,
+			wantParts: [][]int{{1, 2}, {3, 4, 5, 6}},
+		},
+		{
+			name:      "cannot increase partitions",
+			numParts:  10,
+			wantParts: [][]int{{1, 2}, {3, 4}, {5, 6}},
+		},
+		{
+			name:     "invalid partitions",
+			numParts: 0,
+			wantErr:  rdd.ErrInvalidPartitions,
+		},
+	}
+
+	source, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4, 5, 6}, 3)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := source.Coalesce(tt.numParts)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := mustCollect(t, rdd.Glom(r))
+			if !slices.EqualFunc(got, tt.wantParts, slices.Equal[[]int]) {
+				t.Errorf("partitions = %v, want %v", got, tt.wantParts)
+			}
+		})
+	}
+}
+
+// TestRepartitionDebugString verifies that a shuffle starts a new stage.
+func TestRepartitionDebugString(t *testing.T) {
+	ctx := context.Background()
+
+	source, _ := rdd.NewWithPartitions([]int{1, 2, 3}, 2)
+	mapped, _ := source.Map(ctx, func(n int) int { return n + 1 })
+	repartitioned, _ := mapped.Repartition(4)
+
+	expected := "(4) Repartition\n +-(2) Map\n     |  NewWithPartitions\n"
+	if got := repartitioned.ToDebugString(); got != expected {
+		t.Errorf("debug string = %q, want %q", got, expected)
+	}
+}
+
+// TestMapPartitions verifies that setup runs once per partition.
+func TestMapPartitions(t *testing.T) {
+	ctx := context.Background()
+	var setups, teardowns atomic.Int64
+
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4, 5, 6}, 3)
+	mapped, err := rdd.MapPartitions(ctx, r, func(_ context.Context, it iter.Seq[int]) iter.Seq[string] {
+		setups.Add(1)
+		prefix := "v"
+		return func(yield func(string) bool) {
+			defer teardowns.Add(1)
+			for v := range it {
+				if !yield(fmt.Sprintf("%s%d", prefix, v)) {
+					return
+				}
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"v1", "v2", "v3", "v4", "v5", "v6"}
+	if got := mustCollect(t, mapped); !slices.Equal(got, want) {
+		t.Errorf("result = %v, want %v", got, want)
+	}
+	if setups.Load() != 3 || teardowns.Load() != 3 {
+		t.Errorf("setups = %d, teardowns = %d, want 3 each", setups.Load(), teardowns.Load())
+	}
+}
+
+// TestMapPartitionsWithIndex verifies the partition index passed to fn.
+func TestMapPartitionsWithIndex(t *testing.T) {
+	ctx := context.Background()
+
+	r, _ := rdd.NewWithPartitions([]string{"a", "b", "c", "d"}, 2)
+	indexed, err := rdd.MapPartitionsWithIndex(ctx, r, func(_ context.Context, index int, it iter.Seq[string]) iter.Seq[rdd.Pair[int, string]] {
+		return func(yield func(rdd.Pair[int, string]) bool) {
+			for v := range it {
+				if !yield(rdd.Pair[int, string]{Key: index, Value: v}) {
+					return
+				}
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []rdd.Pair[int, string]{{Key: 0, Value: "a"}, {Key: 0, Value: "b"}, {Key: 1, Value: "c"}, {Key: 1, Value: "d"}}
+	if got := mustCollect(t, indexed); !slices.Equal(got, want) {
+		t.Errorf("result = %v, want %v", got, want)
+	}
+}
@@ -1,11 +1,20 @@
 // Package rdd provides a Resilient Distributed Dataset (RDD) implementation
 // for distributed data processing with parallel transformations.
+//
+// Transformations are lazy: they only record how a new RDD is derived from
+// its parent (its lineage). Work happens when an action such as Collect or
+// Count runs, at which point consecutive transformations are fused into a
+// single pass over each partition.
+//
+// Each partition is computed by its own task. A failed task can be retried
+// (see WithRetryPolicy), which recomputes only its partition from the
+// lineage.
 package rdd
 
 import (
 	"context"
 	"fmt"
-	"reflect"
+	"iter"
 	"runtime"
 )
 
@@ -13,390 +22,455 @@ import (
 // collection of objects that can be processed in parallel.
 // RDD uses Go generics for type safety.
 type RDD[T any] struct {
-	data []T
-	size int
+	op        string
+	numParts  int
+	compute   func(t *task, split int) iter.Seq[T]
+	deps      []dependency
+	unordered bool
+	persist   *persistence
 }
 
 // New creates a new RDD from the provided data slice.
-// The data is copied to ensure immutability.
+// The data is copied to ensure immutability and split into one partition
+// per CPU, or fewer when there are not enough elements.
 func New[T any](data []T) *RDD[T] {
+	numParts := max(min(runtime.NumCPU(), len(data)), 1)
+	return newParallelCollection("New", data, numParts)
+}
+
+// newParallelCollection creates a source RDD over a copy of data split into
+// numParts contiguous partitions of near-equal size.
+func newParallelCollection[T any](op string, data []T, numParts int) *RDD[T] {
 	// Create a copy to ensure immutability
 	dataCopy := make([]T, len(data))
 	copy(dataCopy, data)
 
 	return &RDD[T]{
-		data: dataCopy,
-		size: len(dataCopy),
+		op:       op,
+		numParts: numParts,
+		compute: func(t *task, split int) iter.Seq[T] {
+			start := split * len(dataCopy) / numParts
+			end := (split + 1) * len(dataCopy) / numParts
+			return func(yield func(T) bool) {
+				for _, v := range dataCopy[start:end] {
+					if t.canceled() || !yield(v) {
+						return
+					}
+				}
+			}
+		},
 	}
 }
 
-// Collect returns all elements in the RDD as a slice.
-// This is a terminal operation that returns a copy of the data.
-func (r *RDD[T]) Collect() []T {
-	result := make([]T, r.size)
-	copy(result, r.data)
-	return result
+// FromSeq creates a single-partition RDD over the elements of seq. The
+// sequence is not materialized: it is iterated again by every action that
+// computes the RDD, so it must yield the same elements each time. Use
+// Repartition to spread the elements over more partitions.
+func FromSeq[T any](seq iter.Seq[T]) *RDD[T] {
+	return &RDD[T]{
+		op:       "FromSeq",
+		numParts: 1,
+		compute: func(t *task, _ int) iter.Seq[T] {
+			return func(yield func(T) bool) {
+				for v := range seq {
+					if t.canceled() || !yield(v) {
+						return
+					}
+				}
+			}
+		},
+	}
 }
 
-// Size returns the number of elements in the RDD.
-func (r *RDD[T]) Size() int {
-	return r.size
+// narrow derives an RDD whose partitions are computed by applying f to the
+// matching partition of r. Chains of narrow transformations are fused, so each
+// partition is processed in a single pass without intermediate slices.
+// The derived RDD keeps the ordering mode of r.
+func narrow[T, U any](r *RDD[T], op string, f func(t *task, in iter.Seq[T]) iter.Seq[U]) *RDD[U] {
+	return narrowSplit(r, op, func(t *task, _ int, in iter.Seq[T]) iter.Seq[U] {
+		return f(t, in)
+	})
 }
 
-// Map applies the given function to each element in the RDD and returns
-// a new RDD containing the transformed elements.
-// Processing is done in parallel using worker goroutines.
+// narrowSplit is like narrow, but f also receives the index of the partition
+// it computes. The index may differ from the partition of the task, e.g. for
+// partitions read through Union or Coalesce.
+func narrowSplit[T, U any](r *RDD[T], op string, f func(t *task, split int, in iter.Seq[T]) iter.Seq[U]) *RDD[U] {
+	return &RDD[U]{
+		op:       op,
+		numParts: r.numParts,
+		compute: func(t *task, split int) iter.Seq[U] {
+			return f(t, split, r.iterator(t, split))
+		},
+		deps:      []dependency{{parent: r}},
+		unordered: r.unordered,
+	}
+}
+
+// Unordered returns an RDD with the same elements whose actions may return
+// them in any order. By default every transformation preserves the order of
+// its input and Collect returns partitions in order; an unordered RDD instead
+// appends each partition as soon as its task completes, which avoids holding
+// finished partitions back behind slower ones. Transformations derived from
+// an unordered RDD are unordered as well.
+func (r *RDD[T]) Unordered() *RDD[T] {
+	unordered := *r
+	unordered.unordered = true
+	return &unordered
+}
+
+// Collect returns all elements in the RDD as a slice, in partition order
+// unless the RDD is Unordered.
+// This is an action: it executes the lineage of the RDD.
 //
 // The context can be used to cancel the operation.
 // Returns context.Canceled if the context is canceled during processing.
-func (r *RDD[T]) Map(ctx context.Context, fn func(T) T) (*RDD[T], error) {
-	// Check context before starting
-	if err := ctx.Err(); err != nil {
-		return nil, err
+func (r *RDD[T]) Collect(ctx context.Context) ([]T, error) {
+	if r.unordered {
+		result := []T{}
+		err := runTasks(ctx, r, allPartitions(r.numParts), collectPartition[T], func(_ int, part []T) {
+			result = append(result, part...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
 	}
 
-	if r.size == 0 {
-		return New([]T{}), nil
+	parts, err := runJob(ctx, r, collectPartition[T])
+	if err != nil {
+		return nil, err
 	}
 
-	numWorkers := runtime.NumCPU()
-	if r.size < numWorkers {
-		numWorkers = r.size
+	total := 0
+	for _, part := range parts {
+		total += len(part)
 	}
-
-	// Create channels for work distribution
-	type job struct {
-		index int
-		value T
+	result := make([]T, 0, total)
+	for _, part := range parts {
+		result = append(result, part...)
 	}
-	type result struct {
-		index int
-		value T
+	return result, nil
+}
+
+// collectPartition gathers the elements of a partition into a slice.
+func collectPartition[T any](_ *task, it iter.Seq[T]) []T {
+	var out []T
+	for v := range it {
+		out = append(out, v)
 	}
+	return out
+}
 
-	jobs := make(chan job, r.size)
-	results := make(chan result, r.size)
-	errors := make(chan error, numWorkers)
-
-	// Start workers
-	for i := 0; i < numWorkers; i++ {
-		go func() {
-			for {
-				select {
-				case <-ctx.Done():
-					errors <- ctx.Err()
-					return
-				case j, ok := <-jobs:
-					if !ok {
-						return
-					}
-					// Apply transformation
-					transformed := fn(j.value)
-					select {
-					case results <- result{index: j.index, value: transformed}:
-					case <-ctx.Done():
-						errors <- ctx.Err()
+// All returns an iterator over the elements of the RDD in partition order,
+// paired with a nil error. Partitions are computed in batches of one
+// partition per CPU as iteration proceeds, so at most one batch is held in
+// memory. If a job fails, the iterator yields a zero element with the error
+// and stops.
+// This is an action: it executes the lineage of the RDD.
+//
+// The context can be used to cancel the iteration.
+func (r *RDD[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		partitions := allPartitions(r.numParts)
+		batchSize := runtime.NumCPU()
+
+		for start := 0; start < len(partitions); start += batchSize {
+			batch := partitions[start:min(start+batchSize, len(partitions))]
+			parts, err := runJobOn(ctx, r, batch, collectPartition[T])
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, part := range parts {
+				for _, v := range part {
+					if !yield(v, nil) {
 						return
 					}
 				}
 			}
-		}()
-	}
-
-	// Send jobs
-	go func() {
-		for i, val := range r.data {
-			select {
-			case jobs <- job{index: i, value: val}:
-			case <-ctx.Done():
-				close(jobs)
-				return
-			}
-		}
-		close(jobs)
-	}()
-
-	// Collect results
-	mappedData := make([]T, r.size)
-	for i := 0; i < r.size; i++ {
-		select {
-		case res := <-results:
-			mappedData[res.index] = res.value
-		case err := <-errors:
-			return nil, err
-		case <-ctx.Done():
-			return nil, ctx.Err()
 		}
 	}
-
-	return New(mappedData), nil
 }
 
-// Filter returns a new RDD containing only elements that satisfy the predicate.
-// Processing is done in parallel using worker goroutines.
+// Count returns the number of elements in the RDD.
+// This is an action: it executes the lineage of the RDD.
 //
 // The context can be used to cancel the operation.
 // Returns context.Canceled if the context is canceled during processing.
-func (r *RDD[T]) Filter(ctx context.Context, predicate func(T) bool) (*RDD[T], error) {
-	// Check context before starting
-	if err := ctx.Err(); err != nil {
-		return nil, err
+func (r *RDD[T]) Count(ctx context.Context) (int, error) {
+	counts, err := runJob(ctx, r, func(_ *task, it iter.Seq[T]) int {
+		n := 0
+		for range it {
+			n++
+		}
+		return n
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	if r.size == 0 {
-		return New([]T{}), nil
+	total := 0
+	for _, n := range counts {
+		total += n
 	}
+	return total, nil
+}
 
-	numWorkers := runtime.NumCPU()
+// Map applies the given function to each element in the RDD and returns
+// a new RDD containing the transformed elements.
+//
+// Map is a convenience wrapper around the package-level Map for
+// transformations that keep the element type.
+//
+// Returns the context error if the context is already canceled.
+func (r *RDD[T]) Map(ctx context.Context, fn func(T) T) (*RDD[T], error) {
+	return Map(ctx, r, fn)
+}
 
-	// Create channels for work distribution
-	type job struct {
-		value T
+// Map applies fn to each element of r and returns a new RDD of the results.
+// Unlike the Map method, the output element type may differ from the input.
+// The transformation is lazy and runs when an action is executed.
+//
+// Returns the context error if the context is already canceled.
+func Map[T, U any](ctx context.Context, r *RDD[T], fn func(T) U) (*RDD[U], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	jobs := make(chan job, r.size)
-	results := make(chan []T, numWorkers)
-	errors := make(chan error, numWorkers)
-
-	// Start workers
-	for i := 0; i < numWorkers; i++ {
-		go func() {
-			var filtered []T
-			for {
-				select {
-				case <-ctx.Done():
-					errors <- ctx.Err()
+	return narrow(r, "Map", func(_ *task, in iter.Seq[T]) iter.Seq[U] {
+		return func(yield func(U) bool) {
+			for v := range in {
+				if !yield(fn(v)) {
 					return
-				case j, ok := <-jobs:
-					if !ok {
-						// Send local results to results channel
-						results <- filtered
-						return
-					}
-					// Apply predicate
-					if predicate(j.value) {
-						filtered = append(filtered, j.value)
-					}
 				}
 			}
-		}()
+		}
+	}), nil
+}
+
+// MapContext is like Map for functions that need the context of the task
+// applying them, e.g. to add to an Accumulator or to stop when the job is
+// canceled.
+//
+// Returns the context error if the context is already canceled.
+func MapContext[T, U any](ctx context.Context, r *RDD[T], fn func(ctx context.Context, v T) U) (*RDD[U], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	// Send jobs
-	go func() {
-		for _, val := range r.data {
-			select {
-			case jobs <- job{value: val}:
-			case <-ctx.Done():
-				close(jobs)
-				return
+	return narrow(r, "MapContext", func(t *task, in iter.Seq[T]) iter.Seq[U] {
+		return func(yield func(U) bool) {
+			for v := range in {
+				if !yield(fn(t.ctx, v)) {
+					return
+				}
 			}
 		}
-		close(jobs)
-	}()
-
-	// Collect results from all workers
-	var filteredData []T
-	workersDone := 0
-	for workersDone < numWorkers {
-		select {
-		case workerResults := <-results:
-			filteredData = append(filteredData, workerResults...)
-			workersDone++
-		case err := <-errors:
-			return nil, err
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
-	}
-
-	return New(filteredData), nil
+	}), nil
 }
 
-// FlatArray flattens nested arrays/slices into a single-level RDD.
-// For elements that are arrays or slices, it extracts all inner elements.
-// For non-array elements, it includes them as-is.
+// Filter returns a new RDD containing only elements that satisfy the predicate.
+// The output preserves the input order.
+// The transformation is lazy and runs when an action is executed.
 //
-// The context can be used to cancel the operation.
-// Returns context.Canceled if the context is canceled during processing.
-func (r *RDD[T]) FlatArray(ctx context.Context) (*RDD[T], error) {
+// Returns the context error if the context is already canceled.
+func (r *RDD[T]) Filter(ctx context.Context, predicate func(T) bool) (*RDD[T], error) {
 	// Check context before starting
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	if r.size == 0 {
-		return New([]T{}), nil
-	}
+	return narrow(r, "Filter", func(_ *task, in iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			for v := range in {
+				if predicate(v) && !yield(v) {
+					return
+				}
+			}
+		}
+	}), nil
+}
 
-	numWorkers := runtime.NumCPU()
-	chunkSize := r.size / numWorkers
-	if chunkSize == 0 {
-		chunkSize = 1
-		numWorkers = r.size
+// FilterContext is like Filter for predicates that need the context of the
+// task evaluating them, e.g. to add to an Accumulator.
+//
+// Returns the context error if the context is already canceled.
+func (r *RDD[T]) FilterContext(ctx context.Context, predicate func(ctx context.Context, v T) bool) (*RDD[T], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	results := make(chan []T, numWorkers)
-	errors := make(chan error, numWorkers)
+	return narrow(r, "FilterContext", func(t *task, in iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			for v := range in {
+				if predicate(t.ctx, v) && !yield(v) {
+					return
+				}
+			}
+		}
+	}), nil
+}
 
-	// Start workers to process chunks
-	for i := 0; i < numWorkers; i++ {
-		start := i * chunkSize
-		end := start + chunkSize
-		if i == numWorkers-1 {
-			end = r.size
+// FlatArray flattens nested slices into a single-level RDD.
+// For elements that are []T slices, it extracts all inner elements.
+// For other elements, it includes them as-is.
+// The output preserves the input order.
+//
+// Returns the context error if the context is already canceled.
+func (r *RDD[T]) FlatArray(ctx context.Context) (*RDD[T], error) {
+	return FlatMap(ctx, r, func(element T) []T {
+		if inner, ok := any(element).([]T); ok {
+			return inner
 		}
+		return []T{element}
+	})
+}
 
-		go func(start, end int) {
-			var flattened []T
+// FlatMap flattens nested slices and applies a transformation function to each element.
+// It combines FlatArray and Map operations in a single pass for efficiency.
+//
+// Returns the context error if the context is already canceled.
+func (r *RDD[T]) FlatMap(ctx context.Context, fn func(T) T) (*RDD[T], error) {
+	return FlatMap(ctx, r, func(element T) []T {
+		inner, ok := any(element).([]T)
+		if !ok {
+			return []T{fn(element)}
+		}
+		transformed := make([]T, len(inner))
+		for i, v := range inner {
+			transformed[i] = fn(v)
+		}
+		return transformed
+	})
+}
 
-			for _, element := range r.data[start:end] {
-				// Check context periodically
-				select {
-				case <-ctx.Done():
-					errors <- ctx.Err()
-					return
-				default:
-				}
+// FlatMap applies fn to each element of r and concatenates the returned
+// slices into a new RDD, preserving the input order. The output element type
+// may differ from the input.
+// The transformation is lazy and runs when an action is executed.
+//
+// Returns the context error if the context is already canceled.
+func FlatMap[T, U any](ctx context.Context, r *RDD[T], fn func(T) []U) (*RDD[U], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
-				// Check if element is a slice or array using reflection
-				t := reflect.TypeOf(element)
-				if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
-					v := reflect.ValueOf(element)
-					// Extract all elements from the nested structure
-					if innerSlice, ok := v.Interface().([]T); ok {
-						flattened = append(flattened, innerSlice...)
-					} else {
-						// Fallback for type-incompatible slices
-						flattened = append(flattened, element)
+	return narrow(r, "FlatMap", func(_ *task, in iter.Seq[T]) iter.Seq[U] {
+		return func(yield func(U) bool) {
+			for v := range in {
+				for _, out := range fn(v) {
+					if !yield(out) {
+						return
 					}
-				} else {
-					flattened = append(flattened, element)
 				}
 			}
-
-			results <- flattened
-		}(start, end)
-	}
-
-	// Collect results from all workers
-	var flattenedData []T
-	workersDone := 0
-	for workersDone < numWorkers {
-		select {
-		case workerResults := <-results:
-			flattenedData = append(flattenedData, workerResults...)
-			workersDone++
-		case err := <-errors:
-			return nil, err
-		case <-ctx.Done():
-			return nil, ctx.Err()
 		}
-	}
+	}), nil
+}
 
-	return New(flattenedData), nil
+// MapErr is like Map for functions that can fail.
+//
+// Returns the context error if the context is already canceled.
+func (r *RDD[T]) MapErr(ctx context.Context, fn func(T) (T, error)) (*RDD[T], error) {
+	return MapErr(ctx, r, fn)
 }
 
-// FlatMap flattens nested arrays and applies a transformation function to each element.
-// It combines FlatArray and Map operations in a single pass for efficiency.
+// MapErr applies fn to each element of r like Map, for functions that can
+// fail. The first error returned by fn stops the action running the
+// transformation, cancels its remaining tasks and is returned as a
+// *TaskError recording the partition and the index of the element within it.
 //
-// The context can be used to cancel the operation.
-// Returns context.Canceled if the context is canceled during processing.
-func (r *RDD[T]) FlatMap(ctx context.Context, fn func(T) T) (*RDD[T], error) {
+// Returns the context error if the context is already canceled.
+func MapErr[T, U any](ctx context.Context, r *RDD[T], fn func(T) (U, error)) (*RDD[U], error) {
 	// Check context before starting
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	if r.size == 0 {
-		return New([]T{}), nil
-	}
-
-	numWorkers := runtime.NumCPU()
+	return narrowSplit(r, "MapErr", func(t *task, split int, in iter.Seq[T]) iter.Seq[U] {
+		return func(yield func(U) bool) {
+			i := 0
+			for v := range in {
+				out, err := fn(v)
+				if err != nil {
+					t.fail(&TaskError{Partition: split, Index: i, Err: err})
+					return
+				}
+				if !yield(out) {
+					return
+				}
+				i++
+			}
+		}
+	}), nil
+}
 
-	// Create channels for work distribution
-	type job struct {
-		value T
+// FilterErr is like Filter for predicates that can fail. The first error
+// returned by predicate is reported like in MapErr.
+//
+// Returns the context error if the context is already canceled.
+func (r *RDD[T]) FilterErr(ctx context.Context, predicate func(T) (bool, error)) (*RDD[T], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	jobs := make(chan job, r.size)
-	results := make(chan []T, numWorkers)
-	errors := make(chan error, numWorkers)
+	return narrowSplit(r, "FilterErr", func(t *task, split int, in iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			i := 0
+			for v := range in {
+				keep, err := predicate(v)
+				if err != nil {
+					t.fail(&TaskError{Partition: split, Index: i, Err: err})
+					return
+				}
+				if keep && !yield(v) {
+					return
+				}
+				i++
+			}
+		}
+	}), nil
+}
 
-	// Start workers
-	for i := 0; i < numWorkers; i++ {
-		go func() {
-			var processed []T
+// FlatMapErr is like FlatMap for functions that can fail. The first error
+// returned by fn is reported like in MapErr.
+//
+// Returns the context error if the context is already canceled.
+func FlatMapErr[T, U any](ctx context.Context, r *RDD[T], fn func(T) ([]U, error)) (*RDD[U], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
-			for {
-				select {
-				case <-ctx.Done():
-					errors <- ctx.Err()
+	return narrowSplit(r, "FlatMapErr", func(t *task, split int, in iter.Seq[T]) iter.Seq[U] {
+		return func(yield func(U) bool) {
+			i := 0
+			for v := range in {
+				outs, err := fn(v)
+				if err != nil {
+					t.fail(&TaskError{Partition: split, Index: i, Err: err})
 					return
-				case j, ok := <-jobs:
-					if !ok {
-						// Send local results
-						results <- processed
+				}
+				for _, out := range outs {
+					if !yield(out) {
 						return
 					}
-
-					// Check if element is a slice or array
-					t := reflect.TypeOf(j.value)
-					if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
-						v := reflect.ValueOf(j.value)
-						if innerSlice, isSlice := v.Interface().([]T); isSlice {
-							// Process each element in the nested slice
-							for _, inner := range innerSlice {
-								transformed := fn(inner)
-								processed = append(processed, transformed)
-							}
-						} else {
-							// Fallback: treat as single element
-							transformed := fn(j.value)
-							processed = append(processed, transformed)
-						}
-					} else {
-						// Process single element
-						transformed := fn(j.value)
-						processed = append(processed, transformed)
-					}
 				}
+				i++
 			}
-		}()
-	}
-
-	// Send jobs
-	go func() {
-		for _, val := range r.data {
-			select {
-			case jobs <- job{value: val}:
-			case <-ctx.Done():
-				close(jobs)
-				return
-			}
-		}
-		close(jobs)
-	}()
-
-	// Collect results from all workers
-	var flatMappedData []T
-	workersDone := 0
-	for workersDone < numWorkers {
-		select {
-		case workerResults := <-results:
-			flatMappedData = append(flatMappedData, workerResults...)
-			workersDone++
-		case err := <-errors:
-			return nil, err
-		case <-ctx.Done():
-			return nil, ctx.Err()
 		}
-	}
-
-	return New(flatMappedData), nil
+	}), nil
 }
 
 // String returns a string representation of the RDD.
 func (r *RDD[T]) String() string {
-	return fmt.Sprintf("RDD[size=%d]", r.size)
+	return fmt.Sprintf("RDD[op=%s, partitions=%d]", r.op, r.numParts)
 }
@@ -3,12 +3,23 @@ package rdd_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 
 	"mkubasz/quanto/internal/rdd"
 )
 
+// mustCollect runs Collect on r and fails the test on error.
+func mustCollect[T any](t *testing.T, r *rdd.RDD[T]) []T {
+	t.Helper()
+	got, err := r.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	return got
+}
+
 // TestNew verifies RDD creation from various data types.
 func TestNew(t *testing.T) {
 	tests := []struct {
@@ -47,7 +58,7 @@ func TestNew(t *testing.T) {
 				return
 			}
 
-			got := len(r.Collect())
+			got := len(mustCollect(t, r))
 			if got != tt.wantSize {
 				t.Errorf("size = %d, want %d", got, tt.wantSize)
 			}
@@ -110,7 +121,7 @@ func TestMap(t *testing.T) {
 				return
 			}
 
-			got := result.Collect()
+			got := mustCollect(t, result)
 			if len(got) != len(tt.expected) {
 				t.Errorf("length = %d, want %d", len(got), len(tt.expected))
 				return
@@ -180,24 +191,16 @@ func TestFilter(t *testing.T) {
 				return
 			}
 
-			got := result.Collect()
+			got := mustCollect(t, result)
 			if len(got) != len(tt.expected) {
 				t.Errorf("length = %d, want %d", len(got), len(tt.expected))
 				return
 			}
 
-			// Filter may not preserve order in parallel execution,
-			// so check that all expected elements are present.
-			for _, expected := range tt.expected {
-				found := false
-				for _, actual := range got {
-					if actual == expected {
-						found = true
-						break
-					}
-				}
-				if !found {
-					t.Errorf("expected element %v not found in result", expected)
+			// Output preserves the input order.
+			for i, val := range got {
+				if val != tt.expected[i] {
+					t.Errorf("element[%d] = %v, want %v", i, val, tt.expected[i])
 				}
 			}
 		})
@@ -253,7 +256,7 @@ func TestFlatArray(t *testing.T) {
 				return
 			}
 
-			got := len(result.Collect())
+			got := len(mustCollect(t, result))
 			if got != tt.wantSize {
 				t.Errorf("size = %d, want %d", got, tt.wantSize)
 			}
@@ -300,30 +303,303 @@ func TestFlatMap(t *testing.T) {
 				return
 			}
 
-			got := result.Collect()
+			got := mustCollect(t, result)
 			if len(got) != len(tt.expected) {
 				t.Errorf("length = %d, want %d", len(got), len(tt.expected))
 				return
 			}
 
-			// FlatMap may not preserve order in parallel execution,
-			// so check that all expected elements are present.
-			for _, expected := range tt.expected {
-				found := false
-				for _, actual := range got {
-					if actual == expected {
-						found = true
-						break
+			// Output preserves the input order.
+			for i, val := range got {
+				if val != tt.expected[i] {
+					t.Errorf("element[%d] = %v, want %v", i, val, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// TestMapTyped verifies the type-changing package-level Map.
+func TestMapTyped(t *testing.T) {
+	ctx := context.Background()
+	r := rdd.New([]string{"a", "bb", "ccc"})
+
+	result, err := rdd.Map(ctx, r, func(s string) int {
+		return len(s)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := mustCollect(t, result)
+	expected := []int{1, 2, 3}
+	if len(got) != len(expected) {
+		t.Fatalf("length = %d, want %d", len(got), len(expected))
+	}
+	for i, val := range got {
+		if val != expected[i] {
+			t.Errorf("element[%d] = %d, want %d", i, val, expected[i])
+		}
+	}
+}
+
+// TestFlatMapTyped verifies the type-changing package-level FlatMap.
+func TestFlatMapTyped(t *testing.T) {
+	ctx := context.Background()
+	r := rdd.New([]string{"a b", "c", ""})
+
+	result, err := rdd.FlatMap(ctx, r, strings.Fields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := mustCollect(t, result)
+	if !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("result = %v, want [a b c]", got)
+	}
+}
+
+// TestFlatMapTypedCancellation verifies that FlatMap respects context cancellation.
+func TestFlatMapTypedCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel immediately.
+
+	r := rdd.New([]int{1, 2, 3})
+	_, err := rdd.FlatMap(ctx, r, func(n int) []int {
+		return []int{n, n}
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got: %v", err)
+	}
+}
+
+// TestOrderPreservation verifies that Filter, FlatMap and FlatArray keep the
+// input order across many partitions and runs.
+func TestOrderPreservation(t *testing.T) {
+	ctx := context.Background()
+
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = i
+	}
+	r, _ := rdd.NewWithPartitions(data, 16)
+
+	var wantFiltered, wantFlat []int
+	for _, n := range data {
+		if n%3 == 0 {
+			wantFiltered = append(wantFiltered, n)
+		}
+		wantFlat = append(wantFlat, n, -n)
+	}
+
+	for run := 0; run < 5; run++ {
+		filtered, _ := r.Filter(ctx, func(n int) bool { return n%3 == 0 })
+		if got := mustCollect(t, filtered); !slices.Equal(got, wantFiltered) {
+			t.Fatalf("run %d: Filter did not preserve order", run)
+		}
+
+		flat, _ := rdd.FlatMap(ctx, r, func(n int) []int { return []int{n, -n} })
+		if got := mustCollect(t, flat); !slices.Equal(got, wantFlat) {
+			t.Fatalf("run %d: FlatMap did not preserve order", run)
+		}
+
+		nested, _ := rdd.Map(ctx, r, func(n int) interface{} { return []interface{}{n, -n} })
+		flattened, _ := nested.FlatArray(ctx)
+		for i, v := range mustCollect(t, flattened) {
+			if v != wantFlat[i] {
+				t.Fatalf("run %d: FlatArray did not preserve order", run)
+			}
+		}
+	}
+}
+
+// TestUnordered verifies the opt-in unordered path returns the same elements.
+func TestUnordered(t *testing.T) {
+	ctx := context.Background()
+
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = i
+	}
+	r, _ := rdd.NewWithPartitions(data, 16)
+
+	filtered, _ := r.Unordered().Filter(ctx, func(n int) bool { return n%2 == 0 })
+
+	got := mustCollect(t, filtered)
+	slices.Sort(got)
+
+	var expected []int
+	for _, n := range data {
+		if n%2 == 0 {
+			expected = append(expected, n)
+		}
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("unordered filter returned %d elements, want %d", len(got), len(expected))
+	}
+}
+
+// errOdd is the error the fallible test functions return for odd elements.
+var errOdd = errors.New("odd element")
+
+// TestMapErr verifies that the first error of a fallible function fails the
+// action with the partition and element index.
+func TestMapErr(t *testing.T) {
+	ctx := context.Background()
+	r, _ := rdd.NewWithPartitions([]int{2, 4, 6, 8, 9, 10}, 2)
+
+	tests := []struct {
+		name      string
+		transform func() (*rdd.RDD[int], error)
+		wantErr   bool
+		want      []int
+	}{
+		{
+			name: "map",
+			transform: func() (*rdd.RDD[int], error) {
+				return r.MapErr(ctx, func(v int) (int, error) {
+					if v%2 == 1 {
+						return 0, errOdd
+					}
+					return v / 2, nil
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "filter",
+			transform: func() (*rdd.RDD[int], error) {
+				return r.FilterErr(ctx, func(v int) (bool, error) {
+					if v%2 == 1 {
+						return false, errOdd
 					}
+					return true, nil
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "flat map",
+			transform: func() (*rdd.RDD[int], error) {
+				return rdd.FlatMapErr(ctx, r, func(v int) ([]int, error) {
+					if v%2 == 1 {
+						return nil, errOdd
+					}
+					return []int{v}, nil
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "no error",
+			transform: func() (*rdd.RDD[int], error) {
+				return rdd.MapErr(ctx, r, func(v int) (int, error) { return v + 1, nil })
+			},
+			want: []int{3, 5, 7, 9, 10, 11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transformed, err := tt.transform()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := transformed.Collect(ctx)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
 				}
-				if !found {
-					t.Errorf("expected element %v not found in result", expected)
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("result = %v, want %v", got, tt.want)
 				}
+				return
+			}
+
+			if !errors.Is(err, errOdd) {
+				t.Fatalf("expected error %v, got %v", errOdd, err)
+			}
+			var taskErr *rdd.TaskError
+			if !errors.As(err, &taskErr) {
+				t.Fatalf("expected *rdd.TaskError, got %T", err)
+			}
+			if taskErr.Partition != 1 || taskErr.Index != 1 {
+				t.Errorf("failed at partition %d, element %d, want partition 1, element 1",
+					taskErr.Partition, taskErr.Index)
 			}
 		})
 	}
 }
 
+// TestMapErrAfterCoalesce verifies that errors name the partition of the
+// failing RDD when a later step merges partitions into one task.
+func TestMapErrAfterCoalesce(t *testing.T) {
+	ctx := context.Background()
+	r, _ := rdd.NewWithPartitions([]int{0, 1, 2, 3, 4, 5}, 3)
+
+	mapped, _ := r.MapErr(ctx, func(v int) (int, error) {
+		if v == 5 {
+			return 0, errOdd
+		}
+		return v, nil
+	})
+	coalesced, err := mapped.Coalesce(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = coalesced.Collect(ctx)
+	var taskErr *rdd.TaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("expected *rdd.TaskError, got %v", err)
+	}
+	if taskErr.Partition != 2 || taskErr.Index != 1 {
+		t.Errorf("failed at partition %d, element %d, want partition 2, element 1",
+			taskErr.Partition, taskErr.Index)
+	}
+}
+
+// TestPanicRecovery verifies that a panic in a user function fails the
+// action instead of crashing the process.
+func TestPanicRecovery(t *testing.T) {
+	ctx := context.Background()
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4}, 4)
+
+	mapped, _ := r.Map(ctx, func(v int) int {
+		if v == 3 {
+			panic("boom")
+		}
+		return v
+	})
+
+	_, err := mapped.Collect(ctx)
+
+	var taskErr *rdd.TaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("expected *rdd.TaskError, got %v", err)
+	}
+	if taskErr.Panic != "boom" {
+		t.Errorf("panic = %v, want boom", taskErr.Panic)
+	}
+	if taskErr.Partition != 2 {
+		t.Errorf("partition = %d, want 2", taskErr.Partition)
+	}
+	if len(taskErr.Stack) == 0 {
+		t.Error("expected a stack trace")
+	}
+
+	// Panics in shuffle map tasks are recovered too.
+	pairs, _ := rdd.Map(ctx, mapped, func(v int) rdd.Pair[int, int] {
+		return rdd.Pair[int, int]{Key: v, Value: v}
+	})
+	grouped, _ := rdd.GroupByKey(ctx, pairs)
+	if _, err := grouped.Count(ctx); !errors.As(err, &taskErr) {
+		t.Errorf("expected *rdd.TaskError, got %v", err)
+	}
+}
+
 // TestMapCancellation verifies that Map respects context cancellation.
 func TestMapCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -385,10 +661,13 @@ func TestConcurrentOperations(t *testing.T) {
 	done := make(chan bool, 3)
 
 	go func() {
-		_, err := r.Map(ctx, func(n interface{}) interface{} {
+		mapped, err := r.Map(ctx, func(n interface{}) interface{} {
 			num, _ := n.(int)
 			return num * 2
 		})
+		if err == nil {
+			_, err = mapped.Collect(ctx)
+		}
 		if err != nil {
 			t.Errorf("Map error: %v", err)
 		}
@@ -396,10 +675,13 @@ func TestConcurrentOperations(t *testing.T) {
 	}()
 
 	go func() {
-		_, err := r.Filter(ctx, func(n interface{}) bool {
+		filtered, err := r.Filter(ctx, func(n interface{}) bool {
 			num, _ := n.(int)
 			return num%2 == 0
 		})
+		if err == nil {
+			_, err = filtered.Collect(ctx)
+		}
 		if err != nil {
 			t.Errorf("Filter error: %v", err)
 		}
@@ -407,7 +689,10 @@ func TestConcurrentOperations(t *testing.T) {
 	}()
 
 	go func() {
-		_, err := r.FlatArray(ctx)
+		flattened, err := r.FlatArray(ctx)
+		if err == nil {
+			_, err = flattened.Collect(ctx)
+		}
 		if err != nil {
 			t.Errorf("FlatArray error: %v", err)
 		}
@@ -433,10 +718,11 @@ func BenchmarkMap(b *testing.B) {
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		_, _ = r.Map(ctx, func(n interface{}) interface{} {
+		mapped, _ := r.Map(ctx, func(n interface{}) interface{} {
 			num, _ := n.(int)
 			return num * 2
 		})
+		_, _ = mapped.Collect(ctx)
 	}
 }
 
@@ -453,10 +739,11 @@ func BenchmarkFilter(b *testing.B) {
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		_, _ = r.Filter(ctx, func(n interface{}) bool {
+		filtered, _ := r.Filter(ctx, func(n interface{}) bool {
 			num, _ := n.(int)
 			return num%2 == 0
 		})
+		_, _ = filtered.Collect(ctx)
 	}
 }
 
@@ -473,10 +760,11 @@ func BenchmarkFlatMap(b *testing.B) {
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		_, _ = r.FlatMap(ctx, func(n interface{}) interface{} {
+		flattened, _ := r.FlatMap(ctx, func(n interface{}) interface{} {
 			num, _ := n.(int)
 			return num * 2
 		})
+		_, _ = flattened.Collect(ctx)
 	}
 }
 
@@ -492,10 +780,79 @@ func BenchmarkMapParallel(b *testing.B) {
 	b.ResetTimer()
 	b.RunParallel(func(pb *testing.PB) {
 		for pb.Next() {
-			_, _ = r.Map(ctx, func(n interface{}) interface{} {
+			mapped, _ := r.Map(ctx, func(n interface{}) interface{} {
 				num, _ := n.(int)
 				return num * 2
 			})
+			_, _ = mapped.Collect(ctx)
 		}
 	})
 }
+
+// TestAll verifies streaming the elements of an RDD.
+func TestAll(t *testing.T) {
+	ctx := context.Background()
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+	r, _ := rdd.NewWithPartitions(data, 32)
+
+	var got []int
+	for v, err := range r.All(ctx) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, data) {
+		t.Errorf("result = %v, want %v", got, data)
+	}
+
+	failing, _ := r.MapErr(ctx, func(v int) (int, error) {
+		if v == 90 {
+			return 0, errOdd
+		}
+		return v, nil
+	})
+	var lastErr error
+	n := 0
+	for _, err := range failing.All(ctx) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		n++
+	}
+	if !errors.Is(lastErr, errOdd) {
+		t.Errorf("expected error %v, got %v", errOdd, lastErr)
+	}
+	if n > 90 {
+		t.Errorf("yielded %d elements before the error, want at most 90", n)
+	}
+}
+
+// TestFromSeq verifies building an RDD from an iterator.
+func TestFromSeq(t *testing.T) {
+	ctx := context.Background()
+	r := rdd.FromSeq(func(yield func(int) bool) {
+		for i := range 10 {
+			if !yield(i * i) {
+				return
+			}
+		}
+	})
+
+	mapped, _ := r.Map(ctx, func(v int) int { return v + 1 })
+	want := []int{1, 2, 5, 10, 17, 26, 37, 50, 65, 82}
+	for range 2 {
+		if got := mustCollect(t, mapped); !slices.Equal(got, want) {
+			t.Errorf("result = %v, want %v", got, want)
+		}
+	}
+
+	repartitioned, _ := r.Repartition(3)
+	if n, err := repartitioned.Count(ctx); err != nil || n != 10 {
+		t.Errorf("count = %d, %v, want 10", n, err)
+	}
+}
@@ -0,0 +1,82 @@
+package rdd
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RetryPolicy controls how failed tasks are retried. A retried task
+// recomputes only its own partition from the lineage of the RDD; partitions
+// that already succeeded are kept.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a task runs before its error fails
+	// the job. Values below 2 disable retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles with every
+	// further attempt, up to MaxBackoff if it is set.
+	Backoff time.Duration
+	// MaxBackoff caps the delay between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Retryable reports whether a task failing with err should be retried.
+	// When nil, every error is retried. Cancellation of the job is never
+	// retried.
+	Retryable func(err error) bool
+}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy returns a copy of ctx whose jobs retry failed tasks
+// according to policy. Without it, tasks are not retried.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// retryPolicyFrom returns the retry policy carried by ctx, or a policy that
+// runs every task once.
+func retryPolicyFrom(ctx context.Context) RetryPolicy {
+	policy, _ := ctx.Value(retryPolicyKey{}).(RetryPolicy)
+	return policy
+}
+
+// retry reports whether a task that failed with err on the given attempt
+// should run again.
+func (p RetryPolicy) retry(attempt int, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// delay returns how long to wait after the given failed attempt.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.Backoff
+	for range attempt - 1 {
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			break
+		}
+		d *= 2
+	}
+	if p.MaxBackoff > 0 {
+		d = min(d, p.MaxBackoff)
+	}
+	return d
+}
+
+// wait sleeps for d, returning false early if ctx is done.
+func wait(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
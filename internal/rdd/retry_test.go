@@ -0,0 +1,169 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// errFlaky is the error returned by flaky test functions.
+var errFlaky = errors.New("flaky failure")
+
+// computedTimes returns how often element v was computed.
+func computedTimes(computed *sync.Map, v int) int64 {
+	n, ok := computed.Load(v)
+	if !ok {
+		return 0
+	}
+	return n.(*atomic.Int64).Load()
+}
+
+// TestRetryRecomputesFailedPartition verifies that only the failed
+// partition is recomputed.
+func TestRetryRecomputesFailedPartition(t *testing.T) {
+	ctx := rdd.WithRetryPolicy(context.Background(), rdd.RetryPolicy{MaxAttempts: 3})
+	var remaining atomic.Int64
+	remaining.Store(2)
+	computed := &sync.Map{}
+	source, _ := rdd.NewWithPartitions([]int{0, 1, 2, 3, 4, 5, 6, 7}, 4)
+	// Element 5, in partition 2, fails twice before succeeding.
+	r, _ := rdd.MapErr(ctx, source, func(v int) (int, error) {
+		n, _ := computed.LoadOrStore(v, new(atomic.Int64))
+		n.(*atomic.Int64).Add(1)
+		if v == 5 && remaining.Add(-1) >= 0 {
+			return 0, errFlaky
+		}
+		return v * 10, nil
+	})
+
+	got, err := r.Collect(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{0, 10, 20, 30, 40, 50, 60, 70}; !slices.Equal(got, want) {
+		t.Errorf("result = %v, want %v", got, want)
+	}
+
+	if n := computedTimes(computed, 4); n != 3 {
+		t.Errorf("element of the failed partition computed %d times, want 3", n)
+	}
+	for _, v := range []int{0, 1, 2, 3, 6, 7} {
+		if n := computedTimes(computed, v); n != 1 {
+			t.Errorf("element %d computed %d times, want 1", v, n)
+		}
+	}
+}
+
+// TestRetryExhausted verifies the error returned once retries run out.
+func TestRetryExhausted(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       rdd.RetryPolicy
+		wantAttempts int
+	}{
+		{name: "no policy", wantAttempts: 1},
+		{name: "max attempts", policy: rdd.RetryPolicy{MaxAttempts: 3}, wantAttempts: 3},
+		{
+			name: "not retryable",
+			policy: rdd.RetryPolicy{
+				MaxAttempts: 3,
+				Retryable:   func(err error) bool { return !errors.Is(err, errFlaky) },
+			},
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.policy.MaxAttempts > 0 {
+				ctx = rdd.WithRetryPolicy(ctx, tt.policy)
+			}
+			source, _ := rdd.NewWithPartitions([]int{0, 1, 2, 3, 4, 5, 6, 7}, 4)
+			r, _ := rdd.MapErr(ctx, source, func(v int) (int, error) {
+				if v == 5 {
+					return 0, errFlaky
+				}
+				return v * 10, nil
+			})
+
+			_, err := r.Collect(ctx)
+
+			var taskErr *rdd.TaskError
+			if !errors.As(err, &taskErr) {
+				t.Fatalf("expected *rdd.TaskError, got %v", err)
+			}
+			if !errors.Is(err, errFlaky) {
+				t.Errorf("expected error %v, got %v", errFlaky, err)
+			}
+			if taskErr.Attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", taskErr.Attempts, tt.wantAttempts)
+			}
+			if taskErr.Partition != 2 || taskErr.Index != 1 {
+				t.Errorf("failed at partition %d, element %d, want partition 2, element 1",
+					taskErr.Partition, taskErr.Index)
+			}
+		})
+	}
+}
+
+// TestRetryBackoff verifies the delay between attempts and that
+// cancellation interrupts it.
+func TestRetryBackoff(t *testing.T) {
+	policy := rdd.RetryPolicy{MaxAttempts: 3, Backoff: 20 * time.Millisecond}
+
+	var remaining atomic.Int64
+	remaining.Store(2)
+	source, _ := rdd.NewWithPartitions([]int{0, 1, 2, 3, 4, 5, 6, 7}, 4)
+	r, _ := rdd.MapErr(context.Background(), source, func(v int) (int, error) {
+		if v == 5 && remaining.Add(-1) >= 0 {
+			return 0, errFlaky
+		}
+		return v * 10, nil
+	})
+	start := time.Now()
+	if _, err := r.Collect(rdd.WithRetryPolicy(context.Background(), policy)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least 60ms of backoff", elapsed)
+	}
+
+	policy.Backoff = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	// Nothing is cached, so the second job runs the failing function again.
+	remaining.Store(2)
+	if _, err := r.Collect(rdd.WithRetryPolicy(ctx, policy)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded error, got: %v", err)
+	}
+}
+
+// TestRetryShuffle verifies that failed shuffle map tasks are retried.
+func TestRetryShuffle(t *testing.T) {
+	ctx := rdd.WithRetryPolicy(context.Background(), rdd.RetryPolicy{MaxAttempts: 2})
+	var remaining atomic.Int64
+	remaining.Store(1)
+	source, _ := rdd.NewWithPartitions([]int{0, 1, 2, 3, 4, 5, 6, 7}, 4)
+	r, _ := rdd.MapErr(ctx, source, func(v int) (int, error) {
+		if v == 5 && remaining.Add(-1) >= 0 {
+			return 0, errFlaky
+		}
+		return v * 10, nil
+	})
+
+	repartitioned, _ := r.Repartition(3)
+	n, err := repartitioned.Count(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 8 {
+		t.Errorf("count = %d, want 8", n)
+	}
+}
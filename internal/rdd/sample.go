@@ -0,0 +1,154 @@
+package rdd
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"math/rand/v2"
+)
+
+// maxSampleAttempts bounds how many times TakeSample resamples when a sample
+// came out smaller than requested.
+const maxSampleAttempts = 10
+
+// Sample returns a random sample of the RDD. Without replacement each element
+// is kept with probability fraction, which must be in [0, 1]. With
+// replacement each element appears a Poisson-distributed number of times with
+// mean fraction, which must not be negative.
+//
+// Sampling is reproducible: the same seed and partitioning give the same
+// sample, as each partition draws from its own generator derived from seed.
+//
+// Returns ErrInvalidFraction if fraction is out of range.
+func (r *RDD[T]) Sample(withReplacement bool, fraction float64, seed uint64) (*RDD[T], error) {
+	if fraction < 0 || (!withReplacement && fraction > 1) || math.IsNaN(fraction) {
+		return nil, fmt.Errorf("sampling rdd: %w: got %v", ErrInvalidFraction, fraction)
+	}
+
+	return narrowSplit(r, "Sample", func(_ *task, split int, in iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			rng := rand.New(rand.NewPCG(seed, uint64(split)))
+			for v := range in {
+				copies := 0
+				if withReplacement {
+					copies = poisson(rng, fraction)
+				} else if rng.Float64() < fraction {
+					copies = 1
+				}
+				for ; copies > 0; copies-- {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		}
+	}), nil
+}
+
+// TakeSample returns exactly num randomly chosen elements of the RDD, or all
+// of them in random order when sampling without replacement from an RDD with
+// fewer than num elements. It counts the RDD, samples with a fraction large
+// enough to get num elements with high probability, and resamples in the
+// rare case it falls short.
+//
+// Returns ErrSampleTooSmall if the sample still falls short after
+// maxSampleAttempts attempts.
+// Returns context.Canceled if the context is canceled during processing.
+func (r *RDD[T]) TakeSample(ctx context.Context, withReplacement bool, num int, seed uint64) ([]T, error) {
+	if num <= 0 {
+		return []T{}, nil
+	}
+
+	total, err := r.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return []T{}, nil
+	}
+
+	rng := rand.New(rand.NewPCG(seed, uint64(num)))
+	if !withReplacement && num >= total {
+		all, err := r.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rng.Shuffle(len(all), func(i, j int) { all[i], all[j] = all[j], all[i] })
+		return all, nil
+	}
+
+	fraction := sampleFraction(num, total, withReplacement)
+	var sample []T
+	for attempt := 0; attempt < maxSampleAttempts && len(sample) < num; attempt++ {
+		sampled, err := r.Sample(withReplacement, fraction, rng.Uint64())
+		if err != nil {
+			return nil, err
+		}
+		if sample, err = sampled.Collect(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(sample) < num {
+		return nil, fmt.Errorf("taking sample: %w: got %d of %d elements after %d attempts",
+			ErrSampleTooSmall, len(sample), num, maxSampleAttempts)
+	}
+
+	rng.Shuffle(len(sample), func(i, j int) { sample[i], sample[j] = sample[j], sample[i] })
+	return sample[:num], nil
+}
+
+// sampleFraction returns a sampling fraction that yields at least num of
+// total elements with high probability, by oversampling a few standard
+// deviations above the expected size.
+func sampleFraction(num, total int, withReplacement bool) float64 {
+	expected := float64(num) + 5*math.Sqrt(float64(num)) + 10
+	fraction := expected / float64(total)
+	if withReplacement {
+		return fraction
+	}
+	return min(fraction, 1)
+}
+
+// knuthMaxMean is the largest mean for which poisson uses Knuth's method,
+// whose cost grows with the mean.
+const knuthMaxMean = 10
+
+// poisson draws from a Poisson distribution with the given mean, using
+// Knuth's multiplication method for small means and Hörmann's transformed
+// rejection (PTRS) for larger ones, which takes constant expected time and
+// doesn't underflow.
+func poisson(rng *rand.Rand, mean float64) int {
+	if mean < knuthMaxMean {
+		limit := math.Exp(-mean)
+		k, p := 0, rng.Float64()
+		for p > limit {
+			k++
+			p *= rng.Float64()
+		}
+		return k
+	}
+
+	logMean := math.Log(mean)
+	b := 0.931 + 2.53*math.Sqrt(mean)
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := rng.Float64() - 0.5
+		v := rng.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + mean + 0.43)
+		if us >= 0.07 && v <= vr {
+			return int(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		logFactorial, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -mean+k*logMean-logFactorial {
+			return int(k)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// TestSample verifies fraction bounds and reproducibility.
+func TestSample(t *testing.T) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = i
+	}
+	r, _ := rdd.NewWithPartitions(data, 8)
+
+	tests := []struct {
+		name            string
+		withReplacement bool
+		fraction        float64
+		wantErr         error
+	}{
+		{name: "without replacement", fraction: 0.1},
+		{name: "with replacement", withReplacement: true, fraction: 0.5},
+		{name: "with replacement above one", withReplacement: true, fraction: 2},
+		{name: "with replacement large fraction", withReplacement: true, fraction: 100},
+		{name: "fraction above one", fraction: 1.5, wantErr: rdd.ErrInvalidFraction},
+		{name: "negative fraction", fraction: -0.1, wantErr: rdd.ErrInvalidFraction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampled, err := r.Sample(tt.withReplacement, tt.fraction, 42)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			first := mustCollect(t, sampled)
+			expected := tt.fraction * 10000
+			if got := float64(len(first)); got < expected*0.8 || got > expected*1.2 {
+				t.Errorf("sample size = %v, want about %v", got, expected)
+			}
+
+			again, _ := r.Sample(tt.withReplacement, tt.fraction, 42)
+			if !slices.Equal(first, mustCollect(t, again)) {
+				t.Error("same seed produced a different sample")
+			}
+		})
+	}
+}
+
+// TestTakeSample verifies exact-size reproducible samples.
+func TestTakeSample(t *testing.T) {
+	ctx := context.Background()
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = i
+	}
+	r, _ := rdd.NewWithPartitions(data, 8)
+
+	sample, err := r.TakeSample(ctx, false, 50, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sample) != 50 {
+		t.Fatalf("sample size = %d, want 50", len(sample))
+	}
+
+	unique := slices.Clone(sample)
+	slices.Sort(unique)
+	if len(slices.Compact(unique)) != 50 {
+		t.Error("sample without replacement has duplicates")
+	}
+
+	again, err := r.TakeSample(ctx, false, 50, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(sample, again) {
+		t.Error("same seed produced a different sample")
+	}
+
+	withReplacement, err := r.TakeSample(ctx, true, 20, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(withReplacement) != 20 {
+		t.Errorf("sample size = %d, want 20", len(withReplacement))
+	}
+
+	tiny := rdd.New([]int{1, 2, 3, 4, 5})
+	large, err := tiny.TakeSample(ctx, true, 20000, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(large) != 20000 {
+		t.Errorf("sample size = %d, want 20000", len(large))
+	}
+
+	small, _ := rdd.NewWithPartitions([]int{1, 2, 3}, 2)
+	all, err := small.TakeSample(ctx, false, 10, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slices.Sort(all)
+	if !slices.Equal(all, []int{1, 2, 3}) {
+		t.Errorf("sample = %v, want all elements", all)
+	}
+}
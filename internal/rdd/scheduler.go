@@ -0,0 +1,263 @@
+package rdd
+
+import (
+	"context"
+	"iter"
+	"runtime"
+	"runtime/debug"
+)
+
+// task is the unit of work that computes a single partition of an RDD.
+// A task is owned by one worker goroutine for its whole lifetime.
+type task struct {
+	ctx       context.Context
+	partition int
+	attempt   int
+	err       error
+	updates   accumulatorUpdates
+}
+
+// taskKey is the context key of the task running user code.
+type taskKey struct{}
+
+// fail records err as the reason the task stopped. Only the first error is kept.
+func (t *task) fail(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+// canceled reports whether the task should stop, recording the context error
+// when the job has been canceled.
+func (t *task) canceled() bool {
+	if t.err != nil {
+		return true
+	}
+	select {
+	case <-t.ctx.Done():
+		t.fail(t.ctx.Err())
+		return true
+	default:
+		return false
+	}
+}
+
+// prepare materializes every shuffle stage in the lineage of r so that its
+// partitions can be computed independently. Stages of different parents are
+// prepared concurrently.
+func (r *RDD[T]) prepare(ctx context.Context) error {
+	if len(r.deps) == 1 {
+		return prepareDependency(ctx, r.deps[0])
+	}
+
+	errors := make(chan error, len(r.deps))
+	for _, dep := range r.deps {
+		go func() {
+			errors <- prepareDependency(ctx, dep)
+		}()
+	}
+
+	var firstErr error
+	for range r.deps {
+		if err := <-errors; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// prepareDependency runs the stage behind dep, or prepares its parent when
+// the dependency is narrow.
+func prepareDependency(ctx context.Context, dep dependency) error {
+	if dep.stage != nil {
+		return dep.stage.materialize(ctx)
+	}
+	return dep.parent.prepare(ctx)
+}
+
+// runJob computes every partition of r on a bounded pool of worker goroutines
+// and applies fn to the elements of each partition. Results are returned in
+// partition order.
+//
+// The first failing task cancels the remaining ones and its error is returned.
+func runJob[T, R any](ctx context.Context, r *RDD[T], fn func(t *task, it iter.Seq[T]) R) ([]R, error) {
+	return runJobOn(ctx, r, allPartitions(r.numParts), fn)
+}
+
+// runJobOn is like runJob but only computes the given partitions. Results are
+// returned in the order of partitions.
+func runJobOn[T, R any](ctx context.Context, r *RDD[T], partitions []int, fn func(t *task, it iter.Seq[T]) R) ([]R, error) {
+	position := make(map[int]int, len(partitions))
+	for i, p := range partitions {
+		position[p] = i
+	}
+
+	results := make([]R, len(partitions))
+	err := runTasks(ctx, r, partitions, fn, func(partition int, res R) {
+		results[position[partition]] = res
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// allPartitions returns the indexes of numParts partitions.
+func allPartitions(numParts int) []int {
+	partitions := make([]int, numParts)
+	for p := range partitions {
+		partitions[p] = p
+	}
+	return partitions
+}
+
+// runTasks computes the given partitions of r like runJob, but hands each
+// result to emit as soon as its task completes. Emit is called from the
+// calling goroutine, in task completion order.
+func runTasks[T, R any](
+	ctx context.Context,
+	r *RDD[T],
+	partitions []int,
+	fn func(t *task, it iter.Seq[T]) R,
+	emit func(partition int, res R),
+) error {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Run the shuffle stages this job depends on
+	if err := r.prepare(ctx); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	numWorkers := max(min(runtime.NumCPU(), len(partitions)), 1)
+	policy := retryPolicyFrom(ctx)
+
+	pending := make(chan int, len(partitions))
+	for _, p := range partitions {
+		pending <- p
+	}
+	close(pending)
+
+	type result struct {
+		partition int
+		value     R
+		updates   *accumulatorUpdates
+	}
+
+	results := make(chan result, len(partitions))
+	errors := make(chan error, numWorkers)
+
+	// Start workers
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			for p := range pending {
+				res, updates, err := runAttempts(ctx, r, p, policy, fn)
+				if err != nil {
+					errors <- err
+					return
+				}
+				results <- result{partition: p, value: res, updates: updates}
+			}
+			errors <- nil
+		}()
+	}
+
+	// Emit results until all workers are done, cancelling the rest on the
+	// first failure
+	var firstErr error
+	for done := 0; done < numWorkers; {
+		select {
+		case res := <-results:
+			res.updates.commit()
+			if firstErr == nil {
+				emit(res.partition, res.value)
+			}
+		case err := <-errors:
+			done++
+			if err != nil && firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+
+	// Emit results sent just before their worker finished
+	close(results)
+	for res := range results {
+		res.updates.commit()
+		emit(res.partition, res.value)
+	}
+	return nil
+}
+
+// runAttempts runs the task computing partition p of r, retrying it
+// according to policy until it succeeds. Only partition p is recomputed.
+// The accumulator updates of the successful attempt are returned for the
+// driver to commit; those of failed attempts are discarded.
+// Task failures are returned as a *TaskError recording the number of
+// attempts, while cancellation of the job returns the context error.
+func runAttempts[T, R any](
+	ctx context.Context,
+	r *RDD[T],
+	p int,
+	policy RetryPolicy,
+	fn func(t *task, it iter.Seq[T]) R,
+) (R, *accumulatorUpdates, error) {
+	for attempt := 1; ; attempt++ {
+		t := &task{partition: p, attempt: attempt}
+		t.ctx = context.WithValue(ctx, taskKey{}, t)
+		res := runTask(t, r, fn)
+		if t.err == nil {
+			t.err = ctx.Err()
+		}
+		if t.err == nil {
+			return res, &t.updates, nil
+		}
+		if ctx.Err() != nil {
+			return res, nil, ctx.Err()
+		}
+		if !policy.retry(attempt, t.err) {
+			return res, nil, taskFailure(t)
+		}
+		if !wait(ctx, policy.delay(attempt)) {
+			return res, nil, ctx.Err()
+		}
+	}
+}
+
+// taskFailure returns the error of the failed task t as a *TaskError.
+func taskFailure(t *task) error {
+	taskErr, ok := t.err.(*TaskError)
+	if !ok {
+		taskErr = &TaskError{Partition: t.partition, Index: -1, Err: t.err}
+	}
+	taskErr.Attempts = t.attempt
+	return taskErr
+}
+
+// runTask applies fn to the partition of r computed by t. A panic in the
+// task is recovered and recorded as a *TaskError, so that it fails the job
+// instead of the process.
+func runTask[T, R any](t *task, r *RDD[T], fn func(t *task, it iter.Seq[T]) R) (res R) {
+	defer func() {
+		if v := recover(); v != nil {
+			err, _ := v.(error)
+			t.err = &TaskError{
+				Partition: t.partition,
+				Index:     -1,
+				Err:       err,
+				Panic:     v,
+				Stack:     debug.Stack(),
+			}
+		}
+	}()
+	return fn(t, r.iterator(t, t.partition))
+}
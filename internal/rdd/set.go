@@ -0,0 +1,153 @@
+package rdd
+
+import (
+	"context"
+	"iter"
+)
+
+// Distinct returns an RDD with the unique elements of r. Elements are
+// de-duplicated within each partition, then hash-shuffled so that equal
+// elements meet in the same partition.
+//
+// Returns the context error if the context is already canceled.
+func Distinct[T comparable](ctx context.Context, r *RDD[T]) (*RDD[T], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	keep := func(struct{}) struct{} { return struct{}{} }
+	merge := func(struct{}, struct{}) struct{} { return struct{}{} }
+	unique := combineByKey(asKeys(r, "Distinct"), "Distinct", keep, merge, merge, keep)
+
+	return narrow(unique, "Distinct", func(_ *task, in iter.Seq[Pair[T, struct{}]]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			for p := range in {
+				if !yield(p.Key) {
+					return
+				}
+			}
+		}
+	}), nil
+}
+
+// Union returns an RDD with the elements of r followed by the elements of
+// other, keeping duplicates. It does not shuffle: the partitions of both RDDs
+// become the partitions of the result.
+//
+// Returns the context error if the context is already canceled.
+func Union[T any](ctx context.Context, r, other *RDD[T]) (*RDD[T], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return &RDD[T]{
+		op:       "Union",
+		numParts: r.numParts + other.numParts,
+		compute: func(t *task, split int) iter.Seq[T] {
+			if split < r.numParts {
+				return r.iterator(t, split)
+			}
+			return other.iterator(t, split-r.numParts)
+		},
+		deps:      []dependency{{parent: r}, {parent: other}},
+		unordered: r.unordered || other.unordered,
+	}, nil
+}
+
+// Intersection returns the unique elements present in both r and other.
+//
+// Returns the context error if the context is already canceled.
+func Intersection[T comparable](ctx context.Context, r, other *RDD[T]) (*RDD[T], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	grouped := coGroup(asKeys(r, "Intersection"), asKeys(other, "Intersection"))
+	return narrow(grouped, "Intersection", func(_ *task, in iter.Seq[Pair[T, CoGrouped[struct{}, struct{}]]]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			for p := range in {
+				if len(p.Value.Left) > 0 && len(p.Value.Right) > 0 && !yield(p.Key) {
+					return
+				}
+			}
+		}
+	}), nil
+}
+
+// Subtract returns the elements of r that are not present in other.
+// Duplicates in r are kept.
+//
+// Returns the context error if the context is already canceled.
+func Subtract[T comparable](ctx context.Context, r, other *RDD[T]) (*RDD[T], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	grouped := coGroup(asKeys(r, "Subtract"), asKeys(other, "Subtract"))
+	return narrow(grouped, "Subtract", func(_ *task, in iter.Seq[Pair[T, CoGrouped[struct{}, struct{}]]]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			for p := range in {
+				if len(p.Value.Right) > 0 {
+					continue
+				}
+				for range p.Value.Left {
+					if !yield(p.Key) {
+						return
+					}
+				}
+			}
+		}
+	}), nil
+}
+
+// Cartesian returns every pair of an element of r with an element of other.
+// The result has one partition per pair of input partitions, each holding
+// the product of the two partitions.
+//
+// Returns the context error if the context is already canceled.
+func Cartesian[T comparable, U any](ctx context.Context, r *RDD[T], other *RDD[U]) (*RDD[Pair[T, U]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return &RDD[Pair[T, U]]{
+		op:       "Cartesian",
+		numParts: r.numParts * other.numParts,
+		compute: func(t *task, split int) iter.Seq[Pair[T, U]] {
+			return func(yield func(Pair[T, U]) bool) {
+				var right []U
+				for u := range other.iterator(t, split%other.numParts) {
+					right = append(right, u)
+				}
+				for v := range r.iterator(t, split/other.numParts) {
+					for _, u := range right {
+						if !yield(Pair[T, U]{Key: v, Value: u}) {
+							return
+						}
+					}
+				}
+			}
+		},
+		deps: []dependency{{parent: r}, {parent: other}},
+	}, nil
+}
+
+// asKeys maps each element of r to a pair with an empty value so that it
+// can go through the key-based shuffles. The step is labelled with op, the
+// set operation it belongs to.
+func asKeys[T comparable](r *RDD[T], op string) *RDD[Pair[T, struct{}]] {
+	return narrow(r, op, func(_ *task, in iter.Seq[T]) iter.Seq[Pair[T, struct{}]] {
+		return func(yield func(Pair[T, struct{}]) bool) {
+			for v := range in {
+				if !yield(Pair[T, struct{}]{Key: v}) {
+					return
+				}
+			}
+		}
+	})
+}
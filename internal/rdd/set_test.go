@@ -0,0 +1,138 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// sorted collects r and returns its elements in ascending order.
+func sorted(t *testing.T, r *rdd.RDD[int]) []int {
+	t.Helper()
+	got := mustCollect(t, r)
+	slices.Sort(got)
+	return got
+}
+
+// TestSetOperations verifies Distinct, Union, Intersection and Subtract.
+func TestSetOperations(t *testing.T) {
+	ctx := context.Background()
+
+	left, _ := rdd.NewWithPartitions([]int{1, 2, 2, 3, 4, 4, 5}, 3)
+	right, _ := rdd.NewWithPartitions([]int{4, 5, 6, 6}, 2)
+
+	tests := []struct {
+		name     string
+		op       func() (*rdd.RDD[int], error)
+		expected []int
+	}{
+		{
+			name:     "distinct",
+			op:       func() (*rdd.RDD[int], error) { return rdd.Distinct(ctx, left) },
+			expected: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:     "union keeps duplicates",
+			op:       func() (*rdd.RDD[int], error) { return rdd.Union(ctx, left, right) },
+			expected: []int{1, 2, 2, 3, 4, 4, 4, 5, 5, 6, 6},
+		},
+		{
+			name:     "intersection is unique",
+			op:       func() (*rdd.RDD[int], error) { return rdd.Intersection(ctx, left, right) },
+			expected: []int{4, 5},
+		},
+		{
+			name:     "subtract keeps duplicates",
+			op:       func() (*rdd.RDD[int], error) { return rdd.Subtract(ctx, left, right) },
+			expected: []int{1, 2, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.op()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := sorted(t, result); !slices.Equal(got, tt.expected) {
+				t.Errorf("result = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestUnionPartitions verifies that Union concatenates partitions in order.
+func TestUnionPartitions(t *testing.T) {
+	left, _ := rdd.NewWithPartitions([]int{1, 2, 3}, 2)
+	right, _ := rdd.NewWithPartitions([]int{4, 5}, 1)
+
+	union, err := rdd.Union(context.Background(), left, right)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if union.NumPartitions() != 3 {
+		t.Errorf("partitions = %d, want 3", union.NumPartitions())
+	}
+	if got := mustCollect(t, union); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("result = %v, want [1 2 3 4 5]", got)
+	}
+}
+
+// TestCartesian verifies the cartesian product.
+func TestCartesian(t *testing.T) {
+	left, _ := rdd.NewWithPartitions([]int{1, 2}, 2)
+	right, _ := rdd.NewWithPartitions([]string{"a", "b", "c"}, 2)
+
+	product, err := rdd.Cartesian(context.Background(), left, right)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if product.NumPartitions() != 4 {
+		t.Errorf("partitions = %d, want 4", product.NumPartitions())
+	}
+
+	expected := []string{"1=a", "1=b", "1=c", "2=a", "2=b", "2=c"}
+	if got := formatPairs(t, product); !slices.Equal(got, expected) {
+		t.Errorf("result = %v, want %v", got, expected)
+	}
+}
+
+// TestSetCancellation verifies that set operations respect context cancellation.
+func TestSetCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel immediately.
+
+	r := rdd.New([]int{1, 2, 2})
+
+	if _, err := rdd.Distinct(ctx, r); !errors.Is(err, context.Canceled) {
+		t.Errorf("Distinct: expected context.Canceled error, got: %v", err)
+	}
+	if _, err := rdd.Union(ctx, r, r); !errors.Is(err, context.Canceled) {
+		t.Errorf("Union: expected context.Canceled error, got: %v", err)
+	}
+	if _, err := rdd.Cartesian(ctx, r, r); !errors.Is(err, context.Canceled) {
+		t.Errorf("Cartesian: expected context.Canceled error, got: %v", err)
+	}
+
+	distinct, _ := rdd.Distinct(context.Background(), r)
+	if _, err := distinct.Collect(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Collect: expected context.Canceled error, got: %v", err)
+	}
+}
+
+// TestDistinctDebugString verifies that the internal steps of Distinct are
+// labelled with it.
+func TestDistinctDebugString(t *testing.T) {
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 2, 3}, 2)
+	distinct, _ := rdd.Distinct(context.Background(), r)
+
+	want := "(2) Distinct\n |  Distinct\n +-(2) Distinct\n     |  NewWithPartitions\n"
+	if got := distinct.ToDebugString(); got != want {
+		t.Errorf("debug string = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package rdd
+
+import (
+	"context"
+	"hash/maphash"
+	"iter"
+	"sync"
+)
+
+// stage is a unit of work that must complete before the tasks reading its
+// output can run.
+type stage interface {
+	materialize(ctx context.Context) error
+}
+
+// shuffle redistributes the elements of a parent RDD into numParts output
+// partitions. Each map task turns its partition into one bucket of elements
+// per output partition. The map side runs once, the first time a job depends
+// on it, and its output is kept for later jobs.
+type shuffle[T, M any] struct {
+	parent   *RDD[T]
+	numParts int
+	split    func(t *task, it iter.Seq[T]) [][]M
+
+	mu     sync.Mutex
+	blocks [][]M
+}
+
+// newShuffle creates a shuffle of r into numParts partitions using split to
+// bucket the elements of each map partition.
+func newShuffle[T, M any](r *RDD[T], numParts int, split func(t *task, it iter.Seq[T]) [][]M) *shuffle[T, M] {
+	return &shuffle[T, M]{
+		parent:   r,
+		numParts: numParts,
+		split:    split,
+	}
+}
+
+// materialize runs the map side of the shuffle if it has not run yet.
+func (s *shuffle[T, M]) materialize(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.blocks != nil {
+		return nil
+	}
+
+	mapOutputs, err := runJob(ctx, s.parent, s.split)
+	if err != nil {
+		return err
+	}
+
+	blocks := make([][]M, s.numParts)
+	for _, buckets := range mapOutputs {
+		for p, bucket := range buckets {
+			blocks[p] = append(blocks[p], bucket...)
+		}
+	}
+	s.blocks = blocks
+	return nil
+}
+
+// dependency returns the shuffle dependency of an RDD reading this shuffle.
+func (s *shuffle[T, M]) dependency() dependency {
+	return dependency{parent: s.parent, stage: s}
+}
+
+// read iterates over the shuffled elements of the given output partition.
+func (s *shuffle[T, M]) read(t *task, split int) iter.Seq[M] {
+	return func(yield func(M) bool) {
+		for _, v := range s.blocks[split] {
+			if t.canceled() || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// hashPartitioner assigns keys to one of numParts partitions by hash.
+type hashPartitioner[K comparable] struct {
+	seed     maphash.Seed
+	numParts int
+}
+
+// newHashPartitioner creates a hash partitioner over numParts partitions.
+func newHashPartitioner[K comparable](numParts int) hashPartitioner[K] {
+	return hashPartitioner[K]{
+		seed:     maphash.MakeSeed(),
+		numParts: numParts,
+	}
+}
+
+// partition returns the partition of key.
+func (h hashPartitioner[K]) partition(key K) int {
+	return int(maphash.Comparable(h.seed, key) % uint64(h.numParts))
+}
@@ -0,0 +1,133 @@
+package rdd
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"math/rand/v2"
+	"slices"
+)
+
+// samplesPerPartition is the number of keys sampled from each partition to
+// choose the range boundaries of a sort.
+const samplesPerPartition = 60
+
+// keyed is an element of a sort shuffle carrying its precomputed sort key.
+type keyed[K, T any] struct {
+	key   K
+	value T
+}
+
+// SortBy returns an RDD with the elements of r sorted by the key returned by
+// keyFn, in ascending or descending order. Elements with equal keys keep
+// their relative order.
+//
+// SortBy runs a job to sample the keys of r and split the key space into
+// ranges of similar size, one per partition. Elements are then shuffled to
+// the partition owning their key range and each partition is sorted by its
+// own task, so concatenating the partitions yields the sorted RDD.
+//
+// Returns context.Canceled if the context is canceled while sampling.
+func SortBy[T any, K cmp.Ordered](ctx context.Context, r *RDD[T], keyFn func(T) K, ascending bool) (*RDD[T], error) {
+	return sortBy(ctx, r, "SortBy", keyFn, ascending)
+}
+
+// SortByKey returns a pair RDD sorted by key, in ascending or descending
+// order. See SortBy for how the sort is distributed.
+//
+// Returns context.Canceled if the context is canceled while sampling.
+func SortByKey[K cmp.Ordered, V any](ctx context.Context, r *RDD[Pair[K, V]], ascending bool) (*RDD[Pair[K, V]], error) {
+	return sortBy(ctx, r, "SortByKey", func(p Pair[K, V]) K { return p.Key }, ascending)
+}
+
+// sortBy builds the range-partitioned RDD behind SortBy and SortByKey.
+func sortBy[T any, K cmp.Ordered](ctx context.Context, r *RDD[T], op string, keyFn func(T) K, ascending bool) (*RDD[T], error) {
+	bounds, err := rangeBounds(ctx, r, keyFn)
+	if err != nil {
+		return nil, err
+	}
+
+	numParts := r.numParts
+	partition := func(key K) int {
+		p, _ := slices.BinarySearchFunc(bounds, key, cmp.Compare[K])
+		if !ascending {
+			p = numParts - 1 - p
+		}
+		return p
+	}
+
+	s := newShuffle(r, numParts, func(_ *task, it iter.Seq[T]) [][]keyed[K, T] {
+		buckets := make([][]keyed[K, T], numParts)
+		for v := range it {
+			key := keyFn(v)
+			p := partition(key)
+			buckets[p] = append(buckets[p], keyed[K, T]{key: key, value: v})
+		}
+		return buckets
+	})
+
+	compare := func(a, b keyed[K, T]) int {
+		if ascending {
+			return cmp.Compare(a.key, b.key)
+		}
+		return cmp.Compare(b.key, a.key)
+	}
+
+	return &RDD[T]{
+		op:       op,
+		numParts: numParts,
+		compute: func(t *task, split int) iter.Seq[T] {
+			return func(yield func(T) bool) {
+				var part []keyed[K, T]
+				for v := range s.read(t, split) {
+					part = append(part, v)
+				}
+				slices.SortStableFunc(part, compare)
+				for _, v := range part {
+					if !yield(v.value) {
+						return
+					}
+				}
+			}
+		},
+		deps: []dependency{s.dependency()},
+	}, nil
+}
+
+// rangeBounds samples the keys of r and returns up to NumPartitions-1
+// ascending, distinct keys splitting the key space into ranges of similar
+// size. Sampling uses a fixed seed so the same data gives the same bounds.
+func rangeBounds[T any, K cmp.Ordered](ctx context.Context, r *RDD[T], keyFn func(T) K) ([]K, error) {
+	samples, err := runJob(ctx, r, func(t *task, it iter.Seq[T]) []K {
+		// Reservoir sampling keeps a uniform sample of unknown-size input
+		rng := rand.New(rand.NewPCG(uint64(r.numParts), uint64(t.partition)))
+		reservoir := make([]K, 0, samplesPerPartition)
+		seen := 0
+		for v := range it {
+			seen++
+			if len(reservoir) < samplesPerPartition {
+				reservoir = append(reservoir, keyFn(v))
+				continue
+			}
+			if i := rng.IntN(seen); i < samplesPerPartition {
+				reservoir[i] = keyFn(v)
+			}
+		}
+		return reservoir
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := slices.Concat(samples...)
+	slices.SortFunc(keys, cmp.Compare[K])
+
+	var bounds []K
+	for i := 1; i < r.numParts && len(keys) > 0; i++ {
+		bound := keys[i*len(keys)/r.numParts]
+		if len(bounds) == 0 || cmp.Less(bounds[len(bounds)-1], bound) {
+			bounds = append(bounds, bound)
+		}
+	}
+	return bounds, nil
+}
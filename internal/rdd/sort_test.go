@@ -0,0 +1,121 @@
+package rdd_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// TestSortBy verifies ascending and descending sorts across partitions.
+func TestSortBy(t *testing.T) {
+	ctx := context.Background()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	data := make([]int, 5000)
+	for i := range data {
+		data[i] = rng.IntN(1000)
+	}
+	r, _ := rdd.NewWithPartitions(data, 8)
+
+	ascending := slices.Clone(data)
+	slices.Sort(ascending)
+	descending := slices.Clone(ascending)
+	slices.Reverse(descending)
+
+	tests := []struct {
+		name      string
+		ascending bool
+		expected  []int
+	}{
+		{name: "ascending", ascending: true, expected: ascending},
+		{name: "descending", ascending: false, expected: descending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rdd.SortBy(ctx, r, func(n int) int { return n }, tt.ascending)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.NumPartitions() != 8 {
+				t.Errorf("partitions = %d, want 8", result.NumPartitions())
+			}
+			if got := mustCollect(t, result); !slices.Equal(got, tt.expected) {
+				t.Error("result is not sorted")
+			}
+
+			// Range partitioning spreads the data over the partitions.
+			for i, part := range mustCollect(t, rdd.Glom(result)) {
+				if len(part) == 0 {
+					t.Errorf("partition %d is empty", i)
+				}
+			}
+		})
+	}
+}
+
+// TestSortByStable verifies that elements with equal keys keep their order.
+func TestSortByStable(t *testing.T) {
+	r, _ := rdd.NewWithPartitions([]string{"bb", "a", "cc", "d", "ee", "f"}, 3)
+
+	result, err := rdd.SortBy(context.Background(), r, func(s string) int { return len(s) }, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"a", "d", "f", "bb", "cc", "ee"}
+	if got := mustCollect(t, result); !slices.Equal(got, expected) {
+		t.Errorf("result = %v, want %v", got, expected)
+	}
+}
+
+// TestSortByKey verifies sorting pair RDDs by key.
+func TestSortByKey(t *testing.T) {
+	r, _ := rdd.NewWithPartitions([]rdd.Pair[string, int]{
+		{Key: "c", Value: 3},
+		{Key: "a", Value: 1},
+		{Key: "d", Value: 4},
+		{Key: "b", Value: 2},
+	}, 2)
+
+	result, err := rdd.SortByKey(context.Background(), r, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := mustCollect(t, result)
+	if !slices.IsSortedFunc(got, func(a, b rdd.Pair[string, int]) int { return cmp.Compare(a.Key, b.Key) }) {
+		t.Errorf("result = %v, want sorted by key", got)
+	}
+	if len(got) != 4 {
+		t.Errorf("length = %d, want 4", len(got))
+	}
+}
+
+// TestSortByEmpty verifies sorting an empty RDD.
+func TestSortByEmpty(t *testing.T) {
+	result, err := rdd.SortBy(context.Background(), rdd.New([]int{}), func(n int) int { return n }, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mustCollect(t, result); len(got) != 0 {
+		t.Errorf("result = %v, want empty", got)
+	}
+}
+
+// TestSortByCancellation verifies that sorting respects context cancellation.
+func TestSortByCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel immediately.
+
+	_, err := rdd.SortBy(ctx, rdd.New([]int{3, 1, 2}), func(n int) int { return n }, true)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got: %v", err)
+	}
+}
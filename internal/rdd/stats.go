@@ -0,0 +1,236 @@
+package rdd
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+)
+
+// Number is the constraint satisfied by the element types of numeric RDDs.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// StatCounter holds summary statistics of numeric values. The mean and
+// variance are tracked with Welford's algorithm, so counters of different
+// partitions can be merged without losing precision.
+type StatCounter struct {
+	// Count is the number of values.
+	Count int
+	// Mean is the arithmetic mean of the values, or 0 if there are none.
+	Mean float64
+	// Sum is the sum of the values.
+	Sum float64
+	// Min is the smallest value, or +Inf if there are none.
+	Min float64
+	// Max is the largest value, or -Inf if there are none.
+	Max float64
+
+	m2 float64 // sum of squared differences from the mean
+}
+
+// newStatCounter returns a counter of no values.
+func newStatCounter() StatCounter {
+	return StatCounter{Min: math.Inf(1), Max: math.Inf(-1)}
+}
+
+// add includes x in the statistics.
+func (s *StatCounter) add(x float64) {
+	s.Count++
+	delta := x - s.Mean
+	s.Mean += delta / float64(s.Count)
+	s.m2 += delta * (x - s.Mean)
+	s.Sum += x
+	s.Min = min(s.Min, x)
+	s.Max = max(s.Max, x)
+}
+
+// merge includes the values counted by other in the statistics.
+func (s *StatCounter) merge(other StatCounter) {
+	switch {
+	case other.Count == 0:
+		return
+	case s.Count == 0:
+		*s = other
+		return
+	}
+
+	n := float64(s.Count + other.Count)
+	delta := other.Mean - s.Mean
+	s.Mean += delta * float64(other.Count) / n
+	s.m2 += other.m2 + delta*delta*float64(s.Count)*float64(other.Count)/n
+	s.Count += other.Count
+	s.Sum += other.Sum
+	s.Min = min(s.Min, other.Min)
+	s.Max = max(s.Max, other.Max)
+}
+
+// Variance returns the population variance of the values, or NaN if there
+// are none.
+func (s StatCounter) Variance() float64 {
+	if s.Count == 0 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.Count)
+}
+
+// SampleVariance returns the sample variance of the values, which divides
+// by Count-1, or NaN if there are fewer than two values.
+func (s StatCounter) SampleVariance() float64 {
+	if s.Count < 2 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.Count-1)
+}
+
+// StdDev returns the population standard deviation of the values.
+func (s StatCounter) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// SampleStdDev returns the sample standard deviation of the values.
+func (s StatCounter) SampleStdDev() float64 {
+	return math.Sqrt(s.SampleVariance())
+}
+
+// String returns a summary of the statistics.
+func (s StatCounter) String() string {
+	return fmt.Sprintf("(count: %d, mean: %g, stdev: %g, max: %g, min: %g)",
+		s.Count, s.Mean, s.StdDev(), s.Max, s.Min)
+}
+
+// Stats computes the count, mean, variance, sum, minimum and maximum of the
+// elements of r in one pass. Each partition is summarized in parallel and the
+// partial results are merged on the driver.
+// This is an action: it executes the lineage of the RDD.
+//
+// The context can be used to cancel the operation.
+func Stats[T Number](ctx context.Context, r *RDD[T]) (StatCounter, error) {
+	counters, err := runJob(ctx, r, func(_ *task, it iter.Seq[T]) StatCounter {
+		s := newStatCounter()
+		for v := range it {
+			s.add(float64(v))
+		}
+		return s
+	})
+	if err != nil {
+		return StatCounter{}, err
+	}
+
+	stats := newStatCounter()
+	for _, s := range counters {
+		stats.merge(s)
+	}
+	return stats, nil
+}
+
+// Histogram counts the elements of r falling into each bucket delimited by
+// the sorted edges in buckets: bucket i holds values v with
+// buckets[i] <= v < buckets[i+1], except for the last bucket, which also
+// holds values equal to its upper edge. Values outside the buckets, and NaN,
+// are not counted.
+// This is an action: it executes the lineage of the RDD.
+//
+// Returns ErrInvalidBuckets if there are fewer than two edges or they are
+// not strictly increasing.
+func Histogram[T Number](ctx context.Context, r *RDD[T], buckets []float64) ([]int, error) {
+	if len(buckets) < 2 {
+		return nil, fmt.Errorf("computing histogram: %w: need at least 2 edges, got %d",
+			ErrInvalidBuckets, len(buckets))
+	}
+	for i := 1; i < len(buckets); i++ {
+		if !(buckets[i-1] < buckets[i]) {
+			return nil, fmt.Errorf("computing histogram: %w: edges must be increasing", ErrInvalidBuckets)
+		}
+	}
+
+	return histogram(ctx, r, slices.Clone(buckets))
+}
+
+// HistogramN divides the range between the minimum and maximum elements of
+// r into n buckets of equal width and counts the elements in each, like
+// Histogram. It returns the n+1 bucket edges and the counts. If all elements
+// are equal, a single bucket holds them all.
+// This is an action: it executes the lineage of the RDD twice, once to find
+// the range and once to count.
+//
+// Returns ErrInvalidBuckets if n is not positive and ErrEmptyRDD if the RDD
+// has no elements.
+func HistogramN[T Number](ctx context.Context, r *RDD[T], n int) ([]float64, []int, error) {
+	if n < 1 {
+		return nil, nil, fmt.Errorf("computing histogram: %w: got %d buckets", ErrInvalidBuckets, n)
+	}
+
+	stats, err := Stats(ctx, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if stats.Count == 0 {
+		return nil, nil, fmt.Errorf("computing histogram: %w", ErrEmptyRDD)
+	}
+	if math.IsNaN(stats.Min) || math.IsInf(stats.Min, 0) || math.IsInf(stats.Max, 0) {
+		return nil, nil, fmt.Errorf("computing histogram: %w: range is not finite", ErrInvalidBuckets)
+	}
+
+	if stats.Min == stats.Max {
+		return []float64{stats.Min, stats.Max}, []int{stats.Count}, nil
+	}
+
+	buckets := make([]float64, n+1)
+	width := (stats.Max - stats.Min) / float64(n)
+	for i := range buckets {
+		buckets[i] = stats.Min + float64(i)*width
+	}
+	buckets[n] = stats.Max
+
+	counts, err := histogram(ctx, r, buckets)
+	if err != nil {
+		return nil, nil, err
+	}
+	return buckets, counts, nil
+}
+
+// histogram counts the elements of r in each of the validated buckets.
+func histogram[T Number](ctx context.Context, r *RDD[T], buckets []float64) ([]int, error) {
+	numBuckets := len(buckets) - 1
+	partials, err := runJob(ctx, r, func(_ *task, it iter.Seq[T]) []int {
+		counts := make([]int, numBuckets)
+		for v := range it {
+			if i, ok := bucketIndex(buckets, float64(v)); ok {
+				counts[i]++
+			}
+		}
+		return counts
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, numBuckets)
+	for _, partial := range partials {
+		for i, n := range partial {
+			counts[i] += n
+		}
+	}
+	return counts, nil
+}
+
+// bucketIndex returns the bucket of x among the sorted edges in buckets.
+func bucketIndex(buckets []float64, x float64) (int, bool) {
+	last := len(buckets) - 1
+	i, found := slices.BinarySearch(buckets, x)
+	switch {
+	case found && i == last:
+		return last - 1, true
+	case found:
+		return i, true
+	case i == 0 || i > last:
+		return 0, false
+	default:
+		return i - 1, true
+	}
+}
@@ -0,0 +1,149 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// approxEqual reports whether a and b are equal up to rounding errors.
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*max(1, math.Abs(a), math.Abs(b))
+}
+
+// TestStats verifies summary statistics merged across partitions.
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	r, _ := rdd.NewWithPartitions([]int{2, 4, 4, 4, 5, 5, 7, 9}, 3)
+
+	stats, err := rdd.Stats(ctx, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"count", float64(stats.Count), 8},
+		{"mean", stats.Mean, 5},
+		{"sum", stats.Sum, 40},
+		{"min", stats.Min, 2},
+		{"max", stats.Max, 9},
+		{"variance", stats.Variance(), 4},
+		{"stddev", stats.StdDev(), 2},
+		{"sample variance", stats.SampleVariance(), 32.0 / 7},
+	}
+	for _, c := range checks {
+		if !approxEqual(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+// TestStatsPrecision verifies that merging keeps precision for values with
+// a large offset.
+func TestStatsPrecision(t *testing.T) {
+	data := make([]float64, 1000)
+	for i := range data {
+		data[i] = 1e9 + float64(i%2)
+	}
+	r, _ := rdd.NewWithPartitions(data, 7)
+
+	stats, err := rdd.Stats(context.Background(), r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !approxEqual(stats.Variance(), 0.25) {
+		t.Errorf("variance = %v, want 0.25", stats.Variance())
+	}
+}
+
+// TestStatsEmpty verifies statistics of an empty RDD.
+func TestStatsEmpty(t *testing.T) {
+	stats, err := rdd.Stats(context.Background(), rdd.New([]float32{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Count != 0 || !math.IsNaN(stats.Variance()) {
+		t.Errorf("stats = %v, want no values", stats)
+	}
+}
+
+// TestHistogram verifies counting values into given buckets.
+func TestHistogram(t *testing.T) {
+	ctx := context.Background()
+	r, _ := rdd.NewWithPartitions([]float64{-1, 0, 1, 4.9, 5, 9.5, 10, 11, math.NaN()}, 3)
+
+	counts, err := rdd.Histogram(ctx, r, []float64{0, 5, 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(counts, []int{3, 3}) {
+		t.Errorf("counts = %v, want [3 3]", counts)
+	}
+
+	for _, buckets := range [][]float64{{1}, {0, 0}, {5, 1}} {
+		if _, err := rdd.Histogram(ctx, r, buckets); !errors.Is(err, rdd.ErrInvalidBuckets) {
+			t.Errorf("buckets %v: expected error %v, got %v", buckets, rdd.ErrInvalidBuckets, err)
+		}
+	}
+}
+
+// TestHistogramN verifies counting values into evenly spaced buckets.
+func TestHistogramN(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		data        []int
+		n           int
+		wantBuckets []float64
+		wantCounts  []int
+		wantErr     error
+	}{
+		{
+			name:        "even buckets",
+			data:        []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			n:           2,
+			wantBuckets: []float64{0, 5, 10},
+			wantCounts:  []int{5, 6},
+		},
+		{
+			name:        "equal values",
+			data:        []int{3, 3, 3},
+			n:           4,
+			wantBuckets: []float64{3, 3},
+			wantCounts:  []int{3},
+		},
+		{name: "no buckets", data: []int{1}, n: 0, wantErr: rdd.ErrInvalidBuckets},
+		{name: "empty", data: []int{}, n: 2, wantErr: rdd.ErrEmptyRDD},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets, counts, err := rdd.HistogramN(ctx, rdd.New(tt.data), tt.n)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(buckets, tt.wantBuckets) {
+				t.Errorf("buckets = %v, want %v", buckets, tt.wantBuckets)
+			}
+			if !slices.Equal(counts, tt.wantCounts) {
+				t.Errorf("counts = %v, want %v", counts, tt.wantCounts)
+			}
+		})
+	}
+}
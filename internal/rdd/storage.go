@@ -0,0 +1,150 @@
+package rdd
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// StorageLevel controls where the partitions of a persisted RDD are kept.
+type StorageLevel int
+
+const (
+	// StorageNone means the RDD is not persisted and is recomputed by every
+	// action.
+	StorageNone StorageLevel = iota
+	// MemoryOnly keeps partitions in memory. Partitions that do not fit in
+	// the memory budget, or are evicted from it, are recomputed when needed.
+	MemoryOnly
+	// MemoryAndDisk keeps partitions in memory and spills them to disk when
+	// they do not fit in the memory budget or are evicted from it.
+	MemoryAndDisk
+	// DiskOnly serializes partitions to the spill directory only.
+	DiskOnly
+)
+
+// String returns the name of the storage level, e.g. "MEMORY_ONLY".
+func (l StorageLevel) String() string {
+	switch l {
+	case MemoryOnly:
+		return "MEMORY_ONLY"
+	case MemoryAndDisk:
+		return "MEMORY_AND_DISK"
+	case DiskOnly:
+		return "DISK_ONLY"
+	default:
+		return "NONE"
+	}
+}
+
+// nextRDDID allocates the identifiers persisted RDDs store their blocks under.
+var nextRDDID atomic.Uint64
+
+// persistence records how an RDD is persisted and which block managers hold
+// its partitions.
+type persistence struct {
+	id    uint64
+	level StorageLevel
+
+	mu       sync.Mutex
+	managers map[*BlockManager]struct{}
+}
+
+// track records that bm holds blocks of the RDD.
+func (p *persistence) track(bm *BlockManager) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.managers[bm] = struct{}{}
+}
+
+// Persist marks the RDD to be kept at the given storage level once it is
+// computed, so that later actions reuse its partitions instead of
+// recomputing its lineage. Partitions are stored by the block manager of
+// the context the action runs with (see WithBlockManager).
+//
+// Persist changes r itself and returns it for chaining. It should be called
+// before the first action that is meant to populate the cache. Persisting
+// with StorageNone is the same as calling Unpersist.
+func (r *RDD[T]) Persist(level StorageLevel) *RDD[T] {
+	if r.persist != nil && r.persist.level == level {
+		return r
+	}
+	r.Unpersist()
+	if level == StorageNone {
+		return r
+	}
+	r.persist = &persistence{
+		id:       nextRDDID.Add(1),
+		level:    level,
+		managers: make(map[*BlockManager]struct{}),
+	}
+	return r
+}
+
+// Cache persists the RDD with the MemoryOnly storage level.
+func (r *RDD[T]) Cache() *RDD[T] {
+	return r.Persist(MemoryOnly)
+}
+
+// Unpersist marks the RDD as no longer persisted and drops its partitions
+// from memory and disk. It returns r for chaining.
+func (r *RDD[T]) Unpersist() *RDD[T] {
+	p := r.persist
+	if p == nil {
+		return r
+	}
+	r.persist = nil
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for bm := range p.managers {
+		bm.removeRDD(p.id)
+	}
+	clear(p.managers)
+	return r
+}
+
+// StorageLevel returns the storage level the RDD is persisted with, or
+// StorageNone.
+func (r *RDD[T]) StorageLevel() StorageLevel {
+	if r.persist == nil {
+		return StorageNone
+	}
+	return r.persist.level
+}
+
+// iterator returns the elements of the given partition, reading them from
+// the block manager when the RDD is persisted and computing them otherwise.
+// Dependent RDDs read their parents through iterator rather than compute.
+func (r *RDD[T]) iterator(t *task, split int) iter.Seq[T] {
+	p := r.persist
+	if p == nil {
+		return r.compute(t, split)
+	}
+
+	return func(yield func(T) bool) {
+		bm := blockManagerFrom(t.ctx)
+		id := blockID{rdd: p.id, partition: split}
+
+		data, ok := getBlock[T](bm, id)
+		if !ok {
+			for v := range r.compute(t, split) {
+				data = append(data, v)
+			}
+			if t.err != nil {
+				return
+			}
+			p.track(bm)
+			if err := putBlock(bm, id, p.level, data); err != nil {
+				t.fail(err)
+				return
+			}
+		}
+
+		for _, v := range data {
+			if t.canceled() || !yield(v) {
+				return
+			}
+		}
+	}
+}
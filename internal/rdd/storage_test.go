@@ -0,0 +1,262 @@
+package rdd_test
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// TestPersist verifies that persisted partitions are reused by later actions
+// at every storage level.
+func TestPersist(t *testing.T) {
+	levels := []rdd.StorageLevel{rdd.MemoryOnly, rdd.MemoryAndDisk, rdd.DiskOnly}
+
+	for _, level := range levels {
+		t.Run(level.String(), func(t *testing.T) {
+			bm := rdd.NewBlockManager(t.TempDir(), 0)
+			defer bm.Close()
+			ctx := rdd.WithBlockManager(context.Background(), bm)
+
+			data := make([]int, 100)
+			for i := range data {
+				data[i] = i
+			}
+			source, _ := rdd.NewWithPartitions(data, 4)
+
+			var calls atomic.Int64
+			mapped, _ := source.Map(ctx, func(v int) int {
+				calls.Add(1)
+				return v * 2
+			})
+			r := mapped.Persist(level)
+
+			first, err := r.Collect(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			second, err := r.Collect(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(first, second) {
+				t.Errorf("second result = %v, want %v", second, first)
+			}
+			if calls.Load() != 100 {
+				t.Errorf("map ran %d times, want 100", calls.Load())
+			}
+			if r.StorageLevel() != level {
+				t.Errorf("storage level = %v, want %v", r.StorageLevel(), level)
+			}
+		})
+	}
+}
+
+// TestPersistInterfaceElements verifies reading back spilled partitions of
+// an RDD whose elements are interfaces.
+func TestPersistInterfaceElements(t *testing.T) {
+	bm := rdd.NewBlockManager(t.TempDir(), 0)
+	defer bm.Close()
+	ctx := rdd.WithBlockManager(context.Background(), bm)
+
+	var calls atomic.Int64
+	r, _ := rdd.NewWithPartitions([]any{1, "two", 3.5, true}, 2)
+	mapped, _ := rdd.Map(ctx, r, func(v any) any {
+		calls.Add(1)
+		return v
+	})
+	mapped.Persist(rdd.DiskOnly)
+
+	first, err := mapped.Collect(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := mapped.Collect(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(second, first) {
+		t.Errorf("second result = %v, want %v", second, first)
+	}
+	if calls.Load() != 4 {
+		t.Errorf("map ran %d times, want 4", calls.Load())
+	}
+}
+
+// TestPersistReusedByDescendants verifies that RDDs derived from a cached RDD
+// read its partitions instead of recomputing them.
+func TestPersistReusedByDescendants(t *testing.T) {
+	ctx := rdd.WithBlockManager(context.Background(), rdd.NewBlockManager(t.TempDir(), 0))
+
+	data := make([]int, 40)
+	for i := range data {
+		data[i] = i
+	}
+	source, _ := rdd.NewWithPartitions(data, 4)
+
+	var calls atomic.Int64
+	mapped, _ := source.Map(ctx, func(v int) int {
+		calls.Add(1)
+		return v * 2
+	})
+	r := mapped.Cache()
+
+	if _, err := r.Count(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	evens, _ := r.Filter(ctx, func(v int) bool { return v%4 == 0 })
+	got, err := evens.Collect(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 20 {
+		t.Errorf("filtered %d elements, want 20", len(got))
+	}
+	if calls.Load() != 40 {
+		t.Errorf("map ran %d times, want 40", calls.Load())
+	}
+}
+
+// TestUnpersist verifies that unpersisted RDDs are recomputed.
+func TestUnpersist(t *testing.T) {
+	dir := t.TempDir()
+	ctx := rdd.WithBlockManager(context.Background(), rdd.NewBlockManager(dir, 0))
+
+	data := make([]int, 10)
+	for i := range data {
+		data[i] = i
+	}
+	source, _ := rdd.NewWithPartitions(data, 4)
+
+	var calls atomic.Int64
+	mapped, _ := source.Map(ctx, func(v int) int {
+		calls.Add(1)
+		return v * 2
+	})
+	r := mapped.Persist(rdd.DiskOnly)
+
+	if _, err := r.Collect(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.Unpersist()
+	if _, err := r.Collect(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls.Load() != 20 {
+		t.Errorf("map ran %d times, want 20", calls.Load())
+	}
+	if r.StorageLevel() != rdd.StorageNone {
+		t.Errorf("storage level = %v, want %v", r.StorageLevel(), rdd.StorageNone)
+	}
+	if files := spilledFiles(t, dir); len(files) != 0 {
+		t.Errorf("spill directory has %d files after unpersist, want 0", len(files))
+	}
+}
+
+// TestMemoryBudget verifies LRU eviction under the memory budget: evicted
+// MEMORY_ONLY partitions are recomputed while MEMORY_AND_DISK partitions
+// are spilled.
+func TestMemoryBudget(t *testing.T) {
+	// Each partition holds 250 ints, about 2000 bytes, so only one fits.
+	const budget = 3000
+
+	tests := []struct {
+		level     rdd.StorageLevel
+		wantCalls int64
+		wantFiles bool
+	}{
+		{level: rdd.MemoryOnly, wantCalls: 2000},
+		{level: rdd.MemoryAndDisk, wantCalls: 1000, wantFiles: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			dir := t.TempDir()
+			bm := rdd.NewBlockManager(dir, budget)
+			ctx := rdd.WithBlockManager(context.Background(), bm)
+
+			data := make([]int, 1000)
+			for i := range data {
+				data[i] = i
+			}
+			source, _ := rdd.NewWithPartitions(data, 4)
+
+			var calls atomic.Int64
+			mapped, _ := source.Map(ctx, func(v int) int {
+				calls.Add(1)
+				return v * 2
+			})
+			r := mapped.Persist(tt.level)
+
+			for range 2 {
+				got, err := r.Collect(ctx)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(got) != 1000 {
+					t.Fatalf("collected %d elements, want 1000", len(got))
+				}
+			}
+
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("map ran %d times, want %d", calls.Load(), tt.wantCalls)
+			}
+			if used := bm.MemoryUsed(); used > budget {
+				t.Errorf("memory used = %d, want at most %d", used, budget)
+			}
+			if files := spilledFiles(t, dir); (len(files) > 0) != tt.wantFiles {
+				t.Errorf("spilled files = %v, want spilled %v", files, tt.wantFiles)
+			}
+
+			if err := bm.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if files := spilledFiles(t, dir); len(files) != 0 {
+				t.Errorf("spill directory has %d files after close, want 0", len(files))
+			}
+		})
+	}
+}
+
+// TestPersistLineage verifies that the storage level shows in the lineage.
+func TestPersistLineage(t *testing.T) {
+	r := rdd.New([]int{1, 2, 3}).Persist(rdd.MemoryAndDisk)
+	mapped, _ := r.Map(context.Background(), func(v int) int { return v })
+
+	if got := mapped.ToDebugString(); !strings.Contains(got, "New [MEMORY_AND_DISK]") {
+		t.Errorf("debug string = %q, want the storage level of New", got)
+	}
+}
+
+// spilledFiles lists the files below dir.
+func spilledFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files = append(files, entry.Name())
+			continue
+		}
+		inner, err := os.ReadDir(dir + "/" + entry.Name())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, f := range inner {
+			files = append(files, f.Name())
+		}
+	}
+	return files
+}
@@ -0,0 +1,143 @@
+package rdd
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// ZipWithIndex pairs each element with its index in the RDD, counting in
+// partition order, as the key of a pair RDD. Computing the index requires
+// the size of every partition but the last, so unless the RDD has a single
+// partition ZipWithIndex runs a job to count them.
+//
+// The context can be used to cancel the counting job.
+func ZipWithIndex[T any](ctx context.Context, r *RDD[T]) (*RDD[Pair[int, T]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	offsets := make([]int, r.numParts)
+	if r.numParts > 1 {
+		counts, err := runJobOn(ctx, r, allPartitions(r.numParts-1), func(_ *task, it iter.Seq[T]) int {
+			n := 0
+			for range it {
+				n++
+			}
+			return n
+		})
+		if err != nil {
+			return nil, err
+		}
+		for p, n := range counts {
+			offsets[p+1] = offsets[p] + n
+		}
+	}
+
+	return narrowSplit(r, "ZipWithIndex", func(_ *task, split int, in iter.Seq[T]) iter.Seq[Pair[int, T]] {
+		return func(yield func(Pair[int, T]) bool) {
+			i := offsets[split]
+			for v := range in {
+				if !yield(Pair[int, T]{Key: i, Value: v}) {
+					return
+				}
+				i++
+			}
+		}
+	}), nil
+}
+
+// ZipWithUniqueID pairs each element with a unique identifier as the key of
+// a pair RDD. The k-th element of partition p gets k*n+p, where n is the
+// number of partitions, so unlike ZipWithIndex no job is needed, but the
+// identifiers are not consecutive.
+//
+// Returns the context error if the context is already canceled.
+func ZipWithUniqueID[T any](ctx context.Context, r *RDD[T]) (*RDD[Pair[int, T]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	numParts := r.numParts
+	return narrowSplit(r, "ZipWithUniqueID", func(_ *task, split int, in iter.Seq[T]) iter.Seq[Pair[int, T]] {
+		return func(yield func(Pair[int, T]) bool) {
+			id := split
+			for v := range in {
+				if !yield(Pair[int, T]{Key: id, Value: v}) {
+					return
+				}
+				id += numParts
+			}
+		}
+	}), nil
+}
+
+// Zip pairs the i-th element of r with the i-th element of other. Both RDDs
+// must have the same number of partitions and the same number of elements
+// in each partition, as is the case when one is derived from the other by
+// narrow transformations such as Map.
+//
+// Returns ErrZipMismatch if the number of partitions differs. Actions fail
+// with ErrZipMismatch if a pair of partitions differs in size.
+func Zip[T comparable, U any](ctx context.Context, r *RDD[T], other *RDD[U]) (*RDD[Pair[T, U]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if r.numParts != other.numParts {
+		return nil, fmt.Errorf("zipping rdds: %w: %d and %d partitions",
+			ErrZipMismatch, r.numParts, other.numParts)
+	}
+
+	return &RDD[Pair[T, U]]{
+		op:       "Zip",
+		numParts: r.numParts,
+		compute: func(t *task, split int) iter.Seq[Pair[T, U]] {
+			return func(yield func(Pair[T, U]) bool) {
+				next, stop := iter.Pull(other.iterator(t, split))
+				defer stop()
+
+				for v := range r.iterator(t, split) {
+					u, ok := next()
+					if !ok {
+						if t.err == nil {
+							t.fail(fmt.Errorf("zipping partition %d: %w: left side is longer", split, ErrZipMismatch))
+						}
+						return
+					}
+					if !yield(Pair[T, U]{Key: v, Value: u}) {
+						return
+					}
+				}
+				if _, ok := next(); ok && t.err == nil {
+					t.fail(fmt.Errorf("zipping partition %d: %w: right side is longer", split, ErrZipMismatch))
+				}
+			}
+		},
+		deps:      []dependency{{parent: r}, {parent: other}},
+		unordered: r.unordered || other.unordered,
+	}, nil
+}
+
+// KeyBy turns r into a pair RDD keyed by the result of fn on each element.
+//
+// Returns the context error if the context is already canceled.
+func KeyBy[T any, K comparable](ctx context.Context, r *RDD[T], fn func(T) K) (*RDD[Pair[K, T]], error) {
+	// Check context before starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return narrow(r, "KeyBy", func(_ *task, in iter.Seq[T]) iter.Seq[Pair[K, T]] {
+		return func(yield func(Pair[K, T]) bool) {
+			for v := range in {
+				if !yield(Pair[K, T]{Key: fn(v), Value: v}) {
+					return
+				}
+			}
+		}
+	}), nil
+}
@@ -0,0 +1,134 @@
+package rdd_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"mkubasz/quanto/internal/rdd"
+)
+
+// TestZipWithIndex verifies consecutive indexes across partitions.
+func TestZipWithIndex(t *testing.T) {
+	ctx := context.Background()
+
+	for _, parts := range []int{1, 3, 7} {
+		r, _ := rdd.NewWithPartitions([]string{"a", "b", "c", "d", "e", "f", "g"}, parts)
+		// An uneven filter makes partition sizes differ.
+		filtered, _ := r.Filter(ctx, func(s string) bool { return s != "b" && s != "f" })
+
+		indexed, err := rdd.ZipWithIndex(ctx, filtered)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []rdd.Pair[int, string]{
+			{Key: 0, Value: "a"}, {Key: 1, Value: "c"}, {Key: 2, Value: "d"},
+			{Key: 3, Value: "e"}, {Key: 4, Value: "g"},
+		}
+		if got := mustCollect(t, indexed); !slices.Equal(got, want) {
+			t.Errorf("%d partitions: result = %v, want %v", parts, got, want)
+		}
+	}
+}
+
+// TestZipWithIndexUnion verifies indexes of partitions read through Union.
+func TestZipWithIndexUnion(t *testing.T) {
+	ctx := context.Background()
+	first, _ := rdd.NewWithPartitions([]string{"a", "b"}, 1)
+	second, _ := rdd.NewWithPartitions([]string{"a", "b", "c", "d"}, 2)
+	left, _ := rdd.ZipWithIndex(ctx, first)
+	right, _ := rdd.ZipWithIndex(ctx, second)
+	union, _ := rdd.Union(ctx, left, right)
+
+	want := []int{0, 1, 0, 1, 2, 3}
+	var keys []int
+	for _, p := range mustCollect(t, union) {
+		keys = append(keys, p.Key)
+	}
+	if !slices.Equal(keys, want) {
+		t.Errorf("indexes = %v, want %v", keys, want)
+	}
+}
+
+// TestZipWithUniqueID verifies that identifiers are unique.
+func TestZipWithUniqueID(t *testing.T) {
+	ctx := context.Background()
+	r, _ := rdd.NewWithPartitions([]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}, 3)
+
+	withIDs, err := rdd.ZipWithUniqueID(ctx, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := mustCollect(t, withIDs)
+	seen := make(map[int]bool)
+	for _, p := range got {
+		if seen[p.Key] {
+			t.Errorf("duplicate id %d", p.Key)
+		}
+		seen[p.Key] = true
+	}
+	if len(got) != 10 {
+		t.Errorf("result has %d elements, want 10", len(got))
+	}
+	if got[0].Key != 0 || got[1].Key != 3 {
+		t.Errorf("first ids = %d, %d, want 0, 3", got[0].Key, got[1].Key)
+	}
+}
+
+// TestZip verifies pairing aligned RDDs and rejecting misaligned ones.
+func TestZip(t *testing.T) {
+	ctx := context.Background()
+	r, _ := rdd.NewWithPartitions([]string{"a", "b", "c", "d", "e"}, 2)
+	doubled, _ := r.Map(ctx, func(s string) string { return s + s })
+	lengths, _ := rdd.Map(ctx, doubled, func(s string) int { return len(s) })
+
+	zipped, err := rdd.Zip(ctx, doubled, lengths)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []rdd.Pair[string, int]{
+		{Key: "aa", Value: 2}, {Key: "bb", Value: 2}, {Key: "cc", Value: 2},
+		{Key: "dd", Value: 2}, {Key: "ee", Value: 2},
+	}
+	if got := mustCollect(t, zipped); !slices.Equal(got, want) {
+		t.Errorf("result = %v, want %v", got, want)
+	}
+
+	regrouped, _ := rdd.NewWithPartitions([]string{"a", "b", "c", "d", "e"}, 3)
+	if _, err := rdd.Zip(ctx, r, regrouped); !errors.Is(err, rdd.ErrZipMismatch) {
+		t.Errorf("expected error %v, got %v", rdd.ErrZipMismatch, err)
+	}
+
+	shorter, _ := r.Filter(ctx, func(s string) bool { return s != "d" })
+	for _, zipped := range []func() (*rdd.RDD[rdd.Pair[string, string]], error){
+		func() (*rdd.RDD[rdd.Pair[string, string]], error) { return rdd.Zip(ctx, r, shorter) },
+		func() (*rdd.RDD[rdd.Pair[string, string]], error) { return rdd.Zip(ctx, shorter, r) },
+	} {
+		z, err := zipped()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := z.Collect(ctx); !errors.Is(err, rdd.ErrZipMismatch) {
+			t.Errorf("expected error %v, got %v", rdd.ErrZipMismatch, err)
+		}
+	}
+}
+
+// TestKeyBy verifies keying elements by a function.
+func TestKeyBy(t *testing.T) {
+	ctx := context.Background()
+	r := rdd.New([]string{"apple", "kiwi", "banana"})
+
+	keyed, err := rdd.KeyBy(ctx, r, func(s string) int { return len(s) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []rdd.Pair[int, string]{{Key: 5, Value: "apple"}, {Key: 4, Value: "kiwi"}, {Key: 6, Value: "banana"}}
+	if got := mustCollect(t, keyed); !slices.Equal(got, want) {
+		t.Errorf("result = %v, want %v", got, want)
+	}
+}
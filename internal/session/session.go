@@ -2,11 +2,16 @@
 package session
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
+
+	"mkubasz/quanto/internal/rdd"
 )
 
 // Mode represents the execution mode for a Quanto session.
@@ -24,6 +29,23 @@ type QuantoSession struct {
 	ID      string
 	AppName string
 	Mode    Mode
+	// SpillDir is the directory persisted RDD partitions are spilled to.
+	// The default temporary directory is used when it is empty.
+	SpillDir string
+	// MemoryBudget is the number of bytes persisted RDD partitions may use
+	// in memory before they are evicted. Zero or less disables the limit.
+	MemoryBudget int64
+	// CheckpointDir is the directory RDDs are checkpointed to when no
+	// directory is given to Checkpoint.
+	CheckpointDir string
+	// Codec serializes checkpointed RDD elements. Gob is used when it is nil.
+	Codec rdd.Codec
+	// RetryPolicy controls how failed RDD tasks are retried.
+	RetryPolicy rdd.RetryPolicy
+
+	mu         sync.Mutex
+	blocks     *rdd.BlockManager
+	broadcasts []interface{ Destroy() }
 }
 
 // New creates a new QuantoSession with a unique identifier.
@@ -46,6 +68,131 @@ func (s *QuantoSession) SetMode(mode string) *QuantoSession {
 	return s
 }
 
+// SetSpillDir sets the directory persisted RDD partitions are spilled to and
+// returns the session for chaining.
+func (s *QuantoSession) SetSpillDir(dir string) *QuantoSession {
+	s.SpillDir = dir
+	return s
+}
+
+// SetMemoryBudget sets the number of bytes persisted RDD partitions may use
+// in memory and returns the session for chaining.
+func (s *QuantoSession) SetMemoryBudget(bytes int64) *QuantoSession {
+	s.MemoryBudget = bytes
+	return s
+}
+
+// SetCheckpointDir sets the default directory RDDs are checkpointed to and
+// returns the session for chaining.
+func (s *QuantoSession) SetCheckpointDir(dir string) *QuantoSession {
+	s.CheckpointDir = dir
+	return s
+}
+
+// SetCodec sets the codec serializing checkpointed RDD elements and returns
+// the session for chaining.
+func (s *QuantoSession) SetCodec(codec rdd.Codec) *QuantoSession {
+	s.Codec = codec
+	return s
+}
+
+// SetRetryPolicy sets how failed RDD tasks are retried and returns the
+// session for chaining.
+func (s *QuantoSession) SetRetryPolicy(policy rdd.RetryPolicy) *QuantoSession {
+	s.RetryPolicy = policy
+	return s
+}
+
+// BlockManager returns the block manager storing the partitions of RDDs
+// persisted by jobs of this session. It is created on first use from the
+// SpillDir and MemoryBudget settings.
+func (s *QuantoSession) BlockManager() *rdd.BlockManager {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.blocks == nil {
+		s.blocks = rdd.NewBlockManager(s.SpillDir, s.MemoryBudget)
+	}
+	return s.blocks
+}
+
+// Context returns a copy of ctx that runs RDD jobs within this session,
+// using its block manager, checkpoint directory, codec and retry policy.
+func (s *QuantoSession) Context(ctx context.Context) context.Context {
+	ctx = rdd.WithBlockManager(ctx, s.BlockManager())
+	if s.CheckpointDir != "" {
+		ctx = rdd.WithCheckpointDir(ctx, s.CheckpointDir)
+	}
+	if s.Codec != nil {
+		ctx = rdd.WithCodec(ctx, s.Codec)
+	}
+	if s.RetryPolicy.MaxAttempts > 1 {
+		ctx = rdd.WithRetryPolicy(ctx, s.RetryPolicy)
+	}
+	return ctx
+}
+
+// Broadcast shares the read-only value with the tasks of the session. In
+// Cluster mode the value is serialized once with the session codec, and
+// each worker decodes it once instead of every task capturing its own copy.
+// The broadcast is destroyed when the session stops.
+func Broadcast[T any](s *QuantoSession, value T) (*rdd.Broadcast[T], error) {
+	var b *rdd.Broadcast[T]
+	if s.Mode == Cluster {
+		codec := s.Codec
+		if codec == nil {
+			codec = rdd.GobCodec{}
+		}
+		var err error
+		if b, err = rdd.NewSerializedBroadcast(value, codec); err != nil {
+			return nil, err
+		}
+	} else {
+		b = rdd.NewBroadcast(value)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.broadcasts = append(s.broadcasts, b)
+	return b, nil
+}
+
+// Stop releases the resources of the session, destroying its broadcasts,
+// dropping persisted RDD partitions and removing their spill directory.
+func (s *QuantoSession) Stop() error {
+	s.mu.Lock()
+	blocks := s.blocks
+	broadcasts := s.broadcasts
+	s.blocks = nil
+	s.broadcasts = nil
+	s.mu.Unlock()
+
+	for _, b := range broadcasts {
+		b.Destroy()
+	}
+
+	if blocks == nil {
+		return nil
+	}
+	return blocks.Close()
+}
+
+// LongAccumulator returns a new accumulator summing int64 values.
+func (s *QuantoSession) LongAccumulator(name string) *rdd.Accumulator[int64] {
+	return rdd.NewAccumulator(name, 0, func(a, b int64) int64 { return a + b })
+}
+
+// FloatAccumulator returns a new accumulator summing float64 values.
+func (s *QuantoSession) FloatAccumulator(name string) *rdd.Accumulator[float64] {
+	return rdd.NewAccumulator(name, 0, func(a, b float64) float64 { return a + b })
+}
+
+// CollectionAccumulator returns a new accumulator concatenating the slices
+// added to it. Elements added by different tasks appear in task completion
+// order.
+func (s *QuantoSession) CollectionAccumulator(name string) *rdd.Accumulator[[]any] {
+	return rdd.NewAccumulator(name, []any{}, func(a, b []any) []any { return slices.Concat(a, b) })
+}
+
 // GetOrCreate returns the existing session or creates a new one if needed.
 func (s *QuantoSession) GetOrCreate() *QuantoSession {
 	return s
@@ -1,8 +1,15 @@
 package session_test
 
 import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"sync/atomic"
 	"testing"
 
+	"mkubasz/quanto/internal/rdd"
 	"mkubasz/quanto/internal/session"
 )
 
@@ -19,3 +26,174 @@ func TestQuantoSessionBasicSettings(t *testing.T) {
 		t.Errorf("Mode is not 'local'")
 	}
 }
+
+func TestQuantoSessionPersistence(t *testing.T) {
+	dir := t.TempDir()
+	sess := session.New().
+		SetSpillDir(dir).
+		SetMemoryBudget(1 << 20)
+
+	r := rdd.New([]int{1, 2, 3}).Persist(rdd.DiskOnly)
+	if _, err := r.Collect(sess.Context(context.Background())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("spill directory has %d entries, want 1", len(entries))
+	}
+
+	if err := sess.Stop(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entries, _ = os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("spill directory has %d entries after Stop, want 0", len(entries))
+	}
+}
+
+func TestQuantoSessionCheckpointDir(t *testing.T) {
+	dir := t.TempDir()
+	sess := session.New().SetCheckpointDir(dir)
+	defer sess.Stop()
+
+	r, err := rdd.New([]int{1, 2, 3}).Checkpoint(sess.Context(context.Background()), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Lineage().Op != "Checkpoint" {
+		t.Errorf("op = %q, want Checkpoint", r.Lineage().Op)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("checkpoint directory has %d entries, want 1", len(entries))
+	}
+}
+
+func TestQuantoSessionRetryPolicy(t *testing.T) {
+	sess := session.New().SetRetryPolicy(rdd.RetryPolicy{MaxAttempts: 2})
+	defer sess.Stop()
+
+	var failed atomic.Bool
+	r, _ := rdd.MapErr(context.Background(), rdd.New([]int{1, 2, 3}), func(v int) (int, error) {
+		if v == 2 && !failed.Swap(true) {
+			return 0, errors.New("transient failure")
+		}
+		return v, nil
+	})
+
+	if _, err := r.Collect(sess.Context(context.Background())); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestQuantoSessionAccumulators(t *testing.T) {
+	sess := session.New()
+	ctx := sess.Context(context.Background())
+	defer sess.Stop()
+
+	count := sess.LongAccumulator("count")
+	total := sess.FloatAccumulator("total")
+	odd := sess.CollectionAccumulator("odd")
+
+	r, _ := rdd.NewWithPartitions([]int{1, 2, 3, 4}, 2)
+	mapped, _ := rdd.MapContext(ctx, r, func(ctx context.Context, v int) int {
+		count.Add(ctx, 1)
+		total.Add(ctx, float64(v)/2)
+		if v%2 == 1 {
+			odd.Add(ctx, []any{v})
+		}
+		return v
+	})
+	if _, err := mapped.Collect(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count.Value() != 4 {
+		t.Errorf("count = %d, want 4", count.Value())
+	}
+	if total.Value() != 5 {
+		t.Errorf("total = %v, want 5", total.Value())
+	}
+	if len(odd.Value()) != 2 {
+		t.Errorf("odd = %v, want 2 elements", odd.Value())
+	}
+}
+
+func TestQuantoSessionBroadcast(t *testing.T) {
+	for _, mode := range []string{"local", "cluster"} {
+		t.Run(mode, func(t *testing.T) {
+			sess := session.New().SetMode(mode)
+
+			b, err := session.Broadcast(sess, map[string]int{"a": 1})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if b.Value()["a"] != 1 {
+				t.Errorf("value = %v, want a=1", b.Value())
+			}
+
+			if err := sess.Stop(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic reading a destroyed broadcast")
+				}
+			}()
+			b.Value()
+		})
+	}
+}
+
+// decodeCountingCodec is a gob codec counting the values it decodes.
+type decodeCountingCodec struct {
+	decoded atomic.Int64
+}
+
+func (c *decodeCountingCodec) NewEncoder(w io.Writer) rdd.Encoder {
+	return gob.NewEncoder(w)
+}
+
+func (c *decodeCountingCodec) NewDecoder(r io.Reader) rdd.Decoder {
+	return decodeCounter{dec: gob.NewDecoder(r), decoded: &c.decoded}
+}
+
+type decodeCounter struct {
+	dec     *gob.Decoder
+	decoded *atomic.Int64
+}
+
+func (d decodeCounter) Decode(v any) error {
+	d.decoded.Add(1)
+	return d.dec.Decode(v)
+}
+
+func TestQuantoSessionBroadcastDecodedOnce(t *testing.T) {
+	codec := &decodeCountingCodec{}
+	sess := session.New().SetMode("cluster").SetCodec(codec)
+	ctx := sess.Context(context.Background())
+	defer sess.Stop()
+
+	b, err := session.Broadcast(sess, map[string]int{"a": 1, "b": 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r, _ := rdd.NewWithPartitions([]string{"a", "b", "a", "b", "c", "a", "b", "a"}, 4)
+	mapped, _ := rdd.Map(ctx, r, func(k string) int {
+		return b.Value()[k]
+	})
+	total, err := mapped.Reduce(ctx, func(x, y int) int { return x + y })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if total != 10 {
+		t.Errorf("total = %d, want 10", total)
+	}
+	if codec.decoded.Load() != 1 {
+		t.Errorf("codec decoded %d times, want 1", codec.decoded.Load())
+	}
+}
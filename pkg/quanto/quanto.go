@@ -2,6 +2,8 @@
 package quanto
 
 import (
+	"context"
+
 	"mkubasz/quanto/internal/dataframe"
 	"mkubasz/quanto/internal/io"
 	"mkubasz/quanto/internal/rdd"
@@ -37,12 +39,42 @@ func (s *Session) ReadCSV(fileName string) (*dataframe.DataFrame, error) {
 // DataFrame is an alias for dataframe.DataFrame providing column-oriented data structure.
 type DataFrame = dataframe.DataFrame
 
+// Row is an alias for dataframe.Row representing a single DataFrame row.
+type Row = dataframe.Row
+
+// Schema is an alias for dataframe.Schema describing the columns of a DataFrame.
+type Schema = dataframe.Schema
+
+// Field is an alias for dataframe.Field describing a single DataFrame column.
+type Field = dataframe.Field
+
+// DType is an alias for dataframe.DType, the data type of a DataFrame column.
+type DType = dataframe.DType
+
+// Expr is an alias for dataframe.Expr, a column expression such as Col("age").Gt(Lit(30)).
+type Expr = dataframe.Expr
+
 // GroupBy is an alias for dataframe.GroupBy for grouped aggregation operations.
 type GroupBy = dataframe.GroupBy
 
 // Mode is an alias for session.Mode representing execution modes.
 type Mode = session.Mode
 
+// StorageLevel is an alias for rdd.StorageLevel controlling where persisted RDDs are kept.
+type StorageLevel = rdd.StorageLevel
+
+// Codec is an alias for rdd.Codec serializing checkpointed RDD elements.
+type Codec = rdd.Codec
+
+// RetryPolicy is an alias for rdd.RetryPolicy controlling how failed tasks are retried.
+type RetryPolicy = rdd.RetryPolicy
+
+// Accumulator is an alias for rdd.Accumulator, a variable tasks add to and the driver reads.
+type Accumulator[T any] = rdd.Accumulator[T]
+
+// Broadcast is an alias for rdd.Broadcast, a read-only value shared by tasks.
+type Broadcast[T any] = rdd.Broadcast[T]
+
 // NewDataFrame creates a new DataFrame from columns and column names.
 func NewDataFrame(columns []interface{}, columnNames []string) (*dataframe.DataFrame, error) {
 	return dataframe.New(columns, columnNames)
@@ -53,9 +85,24 @@ func Count(values []interface{}) int {
 	return dataframe.Count(values)
 }
 
+// Col returns a dataframe expression referring to the named column.
+func Col(name string) dataframe.Expr {
+	return dataframe.Col(name)
+}
+
+// Lit returns a dataframe expression with the same value in every row.
+func Lit(value interface{}) dataframe.Expr {
+	return dataframe.Lit(value)
+}
+
+// When returns a conditional dataframe expression, continued with Expr.When and Expr.Otherwise.
+func When(condition, value dataframe.Expr) dataframe.Expr {
+	return dataframe.When(condition, value)
+}
+
 // NewDataFrameFromRDD creates a DataFrame from an RDD.
-func NewDataFrameFromRDD[T any](r *rdd.RDD[T]) *dataframe.DataFrame {
-	return dataframe.NewFromRDD(r)
+func NewDataFrameFromRDD[T any](ctx context.Context, r *rdd.RDD[T]) (*dataframe.DataFrame, error) {
+	return dataframe.NewFromRDD(ctx, r)
 }
 
 // NewRDD creates a new RDD from a slice of data.
@@ -68,3 +115,26 @@ const (
 	Local   = session.Local
 	Cluster = session.Cluster
 )
+
+// Re-export storage level constants.
+const (
+	MemoryOnly    = rdd.MemoryOnly
+	MemoryAndDisk = rdd.MemoryAndDisk
+	DiskOnly      = rdd.DiskOnly
+)
+
+// Re-export column data types, used by Expr.Cast.
+const (
+	Null    = dataframe.Null
+	Int64   = dataframe.Int64
+	Float64 = dataframe.Float64
+	String  = dataframe.String
+	Bool    = dataframe.Bool
+	Any     = dataframe.Any
+)
+
+// Re-export DataFrame.DropNA modes.
+const (
+	DropAny = dataframe.DropAny
+	DropAll = dataframe.DropAll
+)